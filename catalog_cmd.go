@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"sqlboiler-project/cli"
+	"sqlboiler-project/config"
+	"sqlboiler-project/database"
+	"sqlboiler-project/tenant"
+)
+
+// runCatalog は books / users / movies / favorites サブコマンド。args は "books", "list", ... の形。終了コードを返す。
+func runCatalog(args []string) int {
+	// 接続先のフラグは各サブコマンドのフラグと一緒に解析する
+	var flags *config.Flags
+	cmd, err := cli.Parse(args, os.Stderr, func(fs *flag.FlagSet) { flags = config.RegisterFlags(fs) })
+	if errors.Is(err, flag.ErrHelp) {
+		return cli.ExitOK
+	}
+	if err != nil {
+		log.Println(err)
+		return cli.ExitUsage
+	}
+
+	cfg, err := config.Load(flags)
+	if err != nil {
+		log.Printf("設定読み込みエラー: %v\n", err)
+		return cli.ExitUsage
+	}
+	boil.DebugMode = cfg.App.Debug
+
+	ctx, stop := database.SignalContext(context.Background())
+	defer stop()
+	// RLS で app.tenant_id のテナントの行だけを扱う
+	ctx = tenant.WithTenant(ctx, cfg.App.TenantID)
+
+	db, err := database.Open(ctx, cfg)
+	if err != nil {
+		log.Printf("データベース接続エラー: %v\n", err)
+		return cli.ExitError
+	}
+	defer database.Shutdown(db, shutdownTimeout)
+
+	return cmd.Run(ctx, &cli.Env{
+		Exec: database.NewExecutor(db, cfg.App.Timeouts),
+		In:   os.Stdin,
+		Out:  os.Stdout,
+		Err:  os.Stderr,
+	})
+}
@@ -0,0 +1,296 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"sqlboiler-project/database"
+	"sqlboiler-project/dto"
+	"sqlboiler-project/models"
+)
+
+// 一覧の既定の件数
+const defaultLimit = 50
+
+func init() {
+	commands["books list"] = spec{usage: "[-where filter]... [-limit n] [-o table|json|csv]", maxArgs: 0, setup: booksList}
+	commands["books get"] = spec{usage: "[-o table|json|csv] id", minArgs: 1, maxArgs: 1, setup: booksGet}
+	commands["books add"] = spec{usage: "-title title -author name [-year year] [-o table|json|csv]", maxArgs: 0, setup: booksAdd}
+	commands["books edit"] = spec{usage: "[-title title] [-author name] [-year year|null] [-o table|json|csv] id", minArgs: 1, maxArgs: 1, setup: booksEdit}
+	commands["books delete"] = spec{usage: "[-yes] id...", minArgs: 1, maxArgs: -1, setup: booksDelete}
+	commands["books search"] = spec{usage: "[-limit n] [-o table|json|csv] keyword", minArgs: 1, maxArgs: 1, setup: booksSearch}
+}
+
+// limitMods は -limit をクエリ修飾子にする。0 なら件数を絞らない。
+func limitMods(limit int) ([]qm.QueryMod, error) {
+	switch {
+	case limit < 0:
+		return nil, usageErrorf("limit must not be negative")
+	case limit == 0:
+		return nil, nil
+	}
+
+	return []qm.QueryMod{qm.Limit(limit)}, nil
+}
+
+// validationError は models の検証エラーを使い方のエラーにする
+func validationError(err error) error {
+	var ve *models.ValidationError
+	if errors.As(err, &ve) {
+		return usageErrorf("%s %s", ve.Field, ve.Message)
+	}
+
+	return err
+}
+
+// validateText は必須の文字列を models.ValidateText で検証する
+func validateText(field, s string) (string, error) {
+	s, err := models.ValidateText(field, s)
+	return s, validationError(err)
+}
+
+// parseYear は出版年を読んで models.ValidateYear で検証する。"" と "null" は NULL にする。
+func parseYear(field, s string) (null.Int, error) {
+	if s == "" || s == "null" {
+		return null.Int{}, nil
+	}
+
+	year, err := strconv.Atoi(s)
+	if err != nil {
+		return null.Int{}, usageErrorf("%s must be a year, got %q", field, s)
+	}
+
+	v := null.IntFrom(year)
+	return v, validationError(models.ValidateYear(field, v))
+}
+
+func booksList(fs *flag.FlagSet) runFunc {
+	var where whereFlag
+	fs.Var(&where, "where", whereHelp)
+	limit := fs.Int("limit", defaultLimit, "Maximum number of rows; 0 for all")
+	format := formatFlag(fs)
+
+	return func(ctx context.Context, env *Env, _ []string) error {
+		mods, err := where.mods(models.TableNames.Books, models.Book{})
+		if err != nil {
+			return err
+		}
+		limitMod, err := limitMods(*limit)
+		if err != nil {
+			return err
+		}
+		mods = append(append(mods, limitMod...), qm.OrderBy(models.BookTableColumns.ID+" ASC"))
+
+		books, err := models.Books(mods...).All(ctx, env.Exec)
+		if err != nil {
+			return err
+		}
+
+		return write(env.Out, *format, dto.NewBookPublics(books))
+	}
+}
+
+func booksGet(fs *flag.FlagSet) runFunc {
+	format := formatFlag(fs)
+
+	return func(ctx context.Context, env *Env, args []string) error {
+		id, err := parseID(args[0])
+		if err != nil {
+			return err
+		}
+
+		b, err := findBook(ctx, env.Exec, id)
+		if err != nil {
+			return err
+		}
+
+		return write(env.Out, *format, dto.NewBookPublic(b))
+	}
+}
+
+// findBook は本を読む。無ければ "book N not found" のエラーを返す。
+func findBook(ctx context.Context, exec boil.ContextExecutor, id int) (*models.Book, error) {
+	b, err := models.FindBook(ctx, exec, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, notFound("book", id)
+	}
+
+	return b, err
+}
+
+func booksAdd(fs *flag.FlagSet) runFunc {
+	title := fs.String("title", "", "Title (required)")
+	author := fs.String("author", "", "Author name (required)")
+	year := fs.String("year", "", "Published year")
+	format := formatFlag(fs)
+
+	return func(ctx context.Context, env *Env, _ []string) error {
+		b := &models.Book{}
+
+		var err error
+		if b.Title, err = validateText("title", *title); err != nil {
+			return err
+		}
+		authorName, err := validateText("author", *author)
+		if err != nil {
+			return err
+		}
+		if b.PublishedYear, err = parseYear("year", *year); err != nil {
+			return err
+		}
+
+		// 著者を作ってから本の INSERT に失敗したときに著者だけが残らないようにする
+		err = database.InTx(ctx, env.Exec, func(exec boil.ContextExecutor) error {
+			if err := b.AssignAuthor(ctx, exec, authorName); err != nil {
+				return err
+			}
+			return b.Insert(ctx, exec, boil.Infer())
+		})
+		if err != nil {
+			return err
+		}
+
+		return write(env.Out, *format, dto.NewBookPublic(b))
+	}
+}
+
+func booksEdit(fs *flag.FlagSet) runFunc {
+	title := fs.String("title", "", "New title")
+	author := fs.String("author", "", "New author name")
+	year := fs.String("year", "", "New published year; null to clear")
+	format := formatFlag(fs)
+
+	return func(ctx context.Context, env *Env, args []string) error {
+		id, err := parseID(args[0])
+		if err != nil {
+			return err
+		}
+
+		// 指定されたフラグのカラムだけを更新する。検証は読み込む前に済ませる。
+		set := map[string]bool{}
+		fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+		var cols []string
+		var newTitle, newAuthor string
+		var newYear null.Int
+		if set["title"] {
+			if newTitle, err = validateText("title", *title); err != nil {
+				return err
+			}
+			cols = append(cols, models.BookColumns.Title)
+		}
+		if set["author"] {
+			if newAuthor, err = validateText("author", *author); err != nil {
+				return err
+			}
+			cols = append(cols, models.BookColumns.AuthorName, models.BookColumns.AuthorID)
+		}
+		if set["year"] {
+			if newYear, err = parseYear("year", *year); err != nil {
+				return err
+			}
+			cols = append(cols, models.BookColumns.PublishedYear)
+		}
+		if len(cols) == 0 {
+			return usageErrorf("nothing to edit; pass -title, -author or -year")
+		}
+
+		b, err := findBook(ctx, env.Exec, id)
+		if err != nil {
+			return err
+		}
+		if set["title"] {
+			b.Title = newTitle
+		}
+		if set["year"] {
+			b.PublishedYear = newYear
+		}
+
+		err = database.InTx(ctx, env.Exec, func(exec boil.ContextExecutor) error {
+			if set["author"] {
+				if err := b.AssignAuthor(ctx, exec, newAuthor); err != nil {
+					return err
+				}
+			}
+			_, err := b.Update(ctx, exec, boil.Whitelist(cols...))
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		return write(env.Out, *format, dto.NewBookPublic(b))
+	}
+}
+
+func booksDelete(fs *flag.FlagSet) runFunc {
+	yes := fs.Bool("yes", false, "Delete without asking for confirmation")
+
+	return func(ctx context.Context, env *Env, args []string) error {
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+
+		books, err := models.Books(models.BookWhere.ID.IN(ids), qm.OrderBy(models.BookTableColumns.ID+" ASC")).All(ctx, env.Exec)
+		if err != nil {
+			return err
+		}
+		found := map[int]bool{}
+		for _, b := range books {
+			found[b.ID] = true
+		}
+		for _, id := range ids {
+			if !found[id] {
+				return notFound("book", id)
+			}
+		}
+
+		for _, b := range books {
+			fmt.Fprintf(env.Err, "  %d: %s (%s)\n", b.ID, b.Title, b.AuthorName)
+		}
+		if err := confirm(env, *yes, fmt.Sprintf("Delete %d book(s)?", len(books))); err != nil {
+			return err
+		}
+
+		// スライスの DeleteAll は 1 行ずつの削除フックも実行する
+		n, err := books.DeleteAll(ctx, env.Exec)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(env.Out, "deleted %d book(s)\n", n)
+
+		return nil
+	}
+}
+
+func booksSearch(fs *flag.FlagSet) runFunc {
+	limit := fs.Int("limit", defaultLimit, "Maximum number of rows")
+	format := formatFlag(fs)
+
+	return func(ctx context.Context, env *Env, args []string) error {
+		keyword := strings.TrimSpace(args[0])
+		if keyword == "" {
+			return usageErrorf("keyword must not be empty")
+		}
+		if *limit < 1 {
+			return usageErrorf("limit must be positive")
+		}
+
+		books, err := models.SearchBooks(keyword, *limit).All(ctx, env.Exec)
+		if err != nil {
+			return err
+		}
+
+		return write(env.Out, *format, dto.NewBookPublics(books))
+	}
+}
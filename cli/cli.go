@@ -0,0 +1,215 @@
+// Package cli は生成されたモデルを操作するサブコマンド（books / users / movies / favorites）を提供する。
+// 一覧と取得の結果は表・JSON・CSV で出力し、削除の前には確認する。終了コードはスクリプトから判定できるよう種類ごとに分ける。
+package cli
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// 終了コード
+const (
+	ExitOK       = 0
+	ExitError    = 1 // データベースのエラーなど
+	ExitUsage    = 2 // 引数やフラグ、入力値の誤り
+	ExitNotFound = 3 // 指定した行が無い
+	ExitAborted  = 4 // 確認で中止した
+)
+
+// Env はコマンドを実行する環境
+type Env struct {
+	Exec boil.ContextExecutor
+	In   io.Reader // 確認の応答を読む
+	Out  io.Writer // 結果を書く
+	Err  io.Writer // 確認やエラーを書く
+}
+
+// runFunc は位置引数を受け取ってコマンドを実行する
+type runFunc func(ctx context.Context, env *Env, args []string) error
+
+// spec はサブコマンドの定義
+type spec struct {
+	usage string // 位置引数とフラグの書式
+	// 位置引数の数。max が -1 なら上限なし。
+	minArgs, maxArgs int
+	// setup は fs にフラグを定義し、フラグを読んで実行する関数を返す
+	setup func(fs *flag.FlagSet) runFunc
+}
+
+// commands は "books list" などの名前からサブコマンドを引く
+var commands = map[string]spec{}
+
+// Command は解析済みのサブコマンド
+type Command struct {
+	name string
+	args []string
+	run  runFunc
+}
+
+// IsCommand は name がこのパッケージのサブコマンドのグループ（books など）かを返す
+func IsCommand(name string) bool {
+	for key := range commands {
+		if strings.HasPrefix(key, name+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Parse は args（"books", "list", "-where", ... の形）を解析する。
+// register は各サブコマンドの FlagSet に共通のフラグ（接続先など）を定義する。nil でもよい。
+// 引数に誤りがあれば使い方を stderr に書いてエラーを返す。-h のときは flag.ErrHelp を返す。
+func Parse(args []string, stderr io.Writer, register func(fs *flag.FlagSet)) (*Command, error) {
+	if len(args) < 2 {
+		Usage(stderr)
+		return nil, errors.New("cli: missing subcommand")
+	}
+
+	name := args[0] + " " + args[1]
+	s, ok := commands[name]
+	if !ok {
+		Usage(stderr)
+		return nil, errors.Errorf("cli: unknown subcommand %q", name)
+	}
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: %s %s\n", name, s.usage)
+		fs.PrintDefaults()
+	}
+	if register != nil {
+		register(fs)
+	}
+	run := s.setup(fs)
+
+	// 位置引数の後ろのフラグも読めるよう、位置引数を 1 つずつ取り出しながら解析する
+	var positional []string
+	rest := args[2:]
+	for {
+		if err := fs.Parse(rest); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		rest = fs.Args()[1:]
+	}
+
+	if len(positional) < s.minArgs || (s.maxArgs >= 0 && len(positional) > s.maxArgs) {
+		fs.Usage()
+		return nil, errors.Errorf("cli: %s: wrong number of arguments", name)
+	}
+
+	return &Command{name: name, args: positional, run: run}, nil
+}
+
+// Usage はサブコマンドの一覧を w に書く
+func Usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "subcommands:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s %s\n", name, commands[name].usage)
+	}
+}
+
+// Run はコマンドを実行して終了コードを返す。エラーは env.Err に書く。
+func (c *Command) Run(ctx context.Context, env *Env) int {
+	err := c.run(ctx, env, c.args)
+	if err == nil {
+		return ExitOK
+	}
+
+	fmt.Fprintf(env.Err, "%s: %v\n", c.name, err)
+
+	var ue *usageError
+	switch {
+	case errors.As(err, &ue):
+		return ExitUsage
+	case errors.Is(err, sql.ErrNoRows):
+		return ExitNotFound
+	case errors.Is(err, errAborted):
+		return ExitAborted
+	}
+
+	return ExitError
+}
+
+// usageError は入力値の誤り
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string { return e.msg }
+
+func usageErrorf(format string, args ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// errAborted は確認で中止したときのエラー
+var errAborted = errors.New("aborted")
+
+// notFound は what が見つからなかったエラーを返す。終了コードの判定のため sql.ErrNoRows を包む。
+func notFound(what string, id int) error {
+	return errors.Wrapf(sql.ErrNoRows, "%s %d not found", what, id)
+}
+
+// parseID は位置引数を主キーにする
+func parseID(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil || id < 1 {
+		return 0, usageErrorf("invalid id %q", s)
+	}
+
+	return id, nil
+}
+
+func parseIDs(args []string) ([]int, error) {
+	ids := make([]int, len(args))
+	for i, a := range args {
+		id, err := parseID(a)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// confirm は yes でなければ msg を表示して y / yes の応答を待つ。それ以外や入力の終わりでは errAborted を返す。
+func confirm(env *Env, yes bool, msg string) error {
+	if yes {
+		return nil
+	}
+
+	fmt.Fprintf(env.Err, "%s [y/N]: ", msg)
+	line, err := bufio.NewReader(env.In).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return nil
+	}
+
+	return errAborted
+}
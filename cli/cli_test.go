@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+
+	"sqlboiler-project/dto"
+	"sqlboiler-project/internal/testdb"
+	"sqlboiler-project/models"
+)
+
+// run は 2 冊の本を返す Fake で args を実行し、終了コードと出力を返す。stdin は確認への応答。
+func run(t *testing.T, stdin string, args ...string) (int, string, string, []string) {
+	t.Helper()
+
+	db, f := testdb.OpenFake(t, map[string]testdb.Table{
+		"books": {
+			Cols: []string{"id", "title", "author", "published_year", "created_at", "tenant_id", "author_id"},
+			Rows: [][]driver.Value{
+				{int64(1), "Solaris", "Lem", int64(1961), nil, int64(0), nil},
+				{int64(2), "Fiasco, revised", "Lem", nil, nil, int64(0), nil},
+			},
+		},
+	})
+
+	code, stdout, stderr := runWith(t, db, stdin, args...)
+
+	return code, stdout, stderr, f.Take()
+}
+
+// runWith は exec で args を実行し、終了コードと出力を返す
+func runWith(t *testing.T, exec boil.ContextExecutor, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	cmd, err := Parse(args, &stderr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := cmd.Run(context.Background(), &Env{Exec: exec, In: strings.NewReader(stdin), Out: &stdout, Err: &stderr})
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		{"books"},
+		{"books", "frobnicate"},
+		{"books", "get"},
+		{"books", "get", "1", "2"},
+		{"books", "list", "-o"},
+	} {
+		if _, err := Parse(args, io.Discard, nil); err == nil {
+			t.Errorf("%v: want an error", args)
+		}
+	}
+
+	// フラグは位置引数の後ろにも書ける
+	cmd, err := Parse([]string{"books", "delete", "1", "-yes", "2"}, io.Discard, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(cmd.args, ",") != "1,2" {
+		t.Errorf("args = %v", cmd.args)
+	}
+
+	if !IsCommand("favorites") || IsCommand("seed") {
+		t.Error("IsCommand")
+	}
+}
+
+func TestWhereMods(t *testing.T) {
+	t.Parallel()
+
+	where := whereFlag{"published_year>=2000", "title ~ 100%", "author_id is not null", "created_at<2024-01-02"}
+	mods, err := where.mods(models.TableNames.Books, models.Book{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, args := queries.BuildQuery(models.Books(mods...).Query)
+
+	for _, want := range []string{
+		`"books"."published_year" >= $1`,
+		`"books"."title" ILIKE $2`,
+		`"books"."author_id" IS NOT NULL`,
+		`"books"."created_at" < $3`,
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("missing %q in %s", want, query)
+		}
+	}
+	if len(args) != 3 || args[0] != 2000 || args[1] != `%100\%%` || !args[2].(time.Time).Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("args = %v", args)
+	}
+
+	for _, bad := range []string{"published_year>=soon", "nope=1", "published_year~19", "title is null", "title"} {
+		if _, err := (whereFlag{bad}).mods(models.TableNames.Books, models.Book{}); err == nil {
+			t.Errorf("%q: want an error", bad)
+		}
+	}
+}
+
+func TestWriteFormats(t *testing.T) {
+	t.Parallel()
+
+	year := 1972
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	favs := []dto.FavoriteMoviePublic{
+		{Movie: dto.MoviePublic{ID: 1, Title: "Solaris", ReleaseYear: &year}, FavoritedAt: &at},
+		{Movie: dto.MoviePublic{ID: 2, Title: "Stalker, director's cut"}},
+	}
+
+	tests := []struct {
+		format string
+		v      interface{}
+		want   string
+	}{
+		{formatCSV, favs, "movie.id,movie.title,movie.release_year,favorited_at\n" +
+			"1,Solaris,1972,2024-03-01T00:00:00Z\n" +
+			"2,\"Stalker, director's cut\",,\n"},
+		{formatTable, favs[0], "MOVIE.ID  MOVIE.TITLE  MOVIE.RELEASE_YEAR  FAVORITED_AT\n" +
+			"1         Solaris      1972                2024-03-01T00:00:00Z\n"},
+		{formatTable, []dto.BookPublic{}, "ID  TITLE  AUTHOR_NAME  PUBLISHED_YEAR\n"},
+		{formatJSON, favs[1:], "[\n  {\n    \"movie\": {\n      \"id\": 2,\n      \"title\": \"Stalker, director's cut\",\n" +
+			"      \"release_year\": null\n    },\n    \"favorited_at\": null\n  }\n]\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := write(&buf, tt.format, tt.v); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("%s:\ngot\n%s\nwant\n%s", tt.format, buf.String(), tt.want)
+		}
+	}
+
+	if err := write(io.Discard, "xml", favs); err == nil {
+		t.Error("unknown format: want an error")
+	}
+}
+
+func TestBooksDeleteConfirm(t *testing.T) {
+	t.Parallel()
+
+	hasDelete := func(queries []string) bool {
+		for _, q := range queries {
+			if strings.HasPrefix(q, "DELETE") {
+				return true
+			}
+		}
+		return false
+	}
+
+	code, _, stderr, q := run(t, "n\n", "books", "delete", "1", "2")
+	if code != ExitAborted || hasDelete(q) {
+		t.Errorf("declined: code %d, queries %v", code, q)
+	}
+	if !strings.Contains(stderr, "1: Solaris (Lem)") || !strings.Contains(stderr, "Delete 2 book(s)? [y/N]") {
+		t.Errorf("prompt: %s", stderr)
+	}
+
+	// 入力が無ければ中止する
+	if code, _, _, q := run(t, "", "books", "delete", "1", "2"); code != ExitAborted || hasDelete(q) {
+		t.Errorf("no input: code %d, queries %v", code, q)
+	}
+
+	code, stdout, _, q := run(t, "yes\n", "books", "delete", "1", "2")
+	if code != ExitOK || !hasDelete(q) || stdout != "deleted 2 book(s)\n" {
+		t.Errorf("confirmed: code %d, stdout %q, queries %v", code, stdout, q)
+	}
+
+	if code, _, _, q := run(t, "", "books", "delete", "-yes", "1", "2"); code != ExitOK || !hasDelete(q) {
+		t.Errorf("-yes: code %d, queries %v", code, q)
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{"books", "get", "-o", "csv", "1"}, ExitOK},
+		{[]string{"books", "get", "9"}, ExitNotFound},
+		{[]string{"books", "get", "x"}, ExitUsage},
+		{[]string{"books", "delete", "-yes", "1", "9"}, ExitNotFound},
+		{[]string{"books", "edit", "1"}, ExitUsage},
+		{[]string{"books", "edit", "-year", "0", "1"}, ExitUsage},
+		{[]string{"books", "edit", "-title", "Fiasco", "9"}, ExitNotFound},
+		{[]string{"books", "list", "-where", "pages>100"}, ExitUsage},
+		{[]string{"books", "list", "-o", "xml"}, ExitUsage},
+	}
+
+	for _, tt := range tests {
+		if code, _, stderr, _ := run(t, "", tt.args...); code != tt.code {
+			t.Errorf("%v: code %d, want %d (%s)", tt.args, code, tt.code, stderr)
+		}
+	}
+
+	_, stdout, _, _ := run(t, "", "books", "get", "-o", "csv", "1")
+	if stdout != "id,title,author_name,published_year\n1,Solaris,Lem,1961\n" {
+		t.Errorf("stdout = %q", stdout)
+	}
+}
+
+func TestBooksWithDatabase(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.Open(t)
+	ctx := context.Background()
+
+	for _, args := range [][]string{
+		{"books", "add", "-title", "Solaris", "-author", "Stanisław Lem", "-year", "1961"},
+		{"books", "add", "-title", "100% Lem", "-author", "stanisław  lem"},
+		{"books", "add", "-title", "1000 Cranes", "-author", "Anonymous"},
+	} {
+		if code, _, stderr := runWith(t, db, "", args...); code != ExitOK {
+			t.Fatalf("%v: code %d (%s)", args, code, stderr)
+		}
+	}
+
+	// 表記ゆれのある著者名は同じ著者にまとめる
+	if n, err := models.Authors().Count(ctx, db); err != nil || n != 2 {
+		t.Errorf("authors = %d, %v", n, err)
+	}
+
+	// % は文字どおりに扱う
+	_, stdout, _ := runWith(t, db, "", "books", "list", "-o", "csv", "-where", "title~100%")
+	if stdout != "id,title,author_name,published_year\n2,100% Lem,stanisław  lem,\n" {
+		t.Errorf("list: %q", stdout)
+	}
+
+	if code, _, stderr := runWith(t, db, "", "books", "edit", "-author", "Ursula K. Le Guin", "1"); code != ExitOK {
+		t.Fatalf("edit: code %d (%s)", code, stderr)
+	}
+	b, err := models.FindBook(ctx, db, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	author, err := b.Author().One(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Title != "Solaris" || author.Name != "Ursula K. Le Guin" {
+		t.Errorf("title %q, author %q", b.Title, author.Name)
+	}
+
+	if code, stdout, _ := runWith(t, db, "", "books", "delete", "-yes", "1", "2"); code != ExitOK || stdout != "deleted 2 book(s)\n" {
+		t.Errorf("delete: code %d, stdout %q", code, stdout)
+	}
+	if code, _, _ := runWith(t, db, "", "books", "get", "1"); code != ExitNotFound {
+		t.Errorf("deleted book: code %d", code)
+	}
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"sqlboiler-project/dto"
+	"sqlboiler-project/models"
+)
+
+func init() {
+	commands["favorites list"] = spec{usage: "[-o table|json|csv] user-id", minArgs: 1, maxArgs: 1, setup: favoritesList}
+	commands["favorites add"] = spec{usage: "user-id movie-id", minArgs: 2, maxArgs: 2, setup: favoritesAdd}
+	commands["favorites remove"] = spec{usage: "[-yes] user-id movie-id", minArgs: 2, maxArgs: 2, setup: favoritesRemove}
+}
+
+func favoritesList(fs *flag.FlagSet) runFunc {
+	format := formatFlag(fs)
+
+	return func(ctx context.Context, env *Env, args []string) error {
+		id, err := parseID(args[0])
+		if err != nil {
+			return err
+		}
+
+		u, err := findUser(ctx, env.Exec, id)
+		if err != nil {
+			return err
+		}
+		favs, err := u.FavoriteMovies(models.FavoritedAtDesc).All(ctx, env.Exec)
+		if err != nil {
+			return err
+		}
+
+		return write(env.Out, *format, dto.NewFavoriteMoviePublics(favs))
+	}
+}
+
+// favoriteArgs はユーザーと映画の id を読み、ユーザーを読んで映画があるか確かめる
+func favoriteArgs(ctx context.Context, env *Env, args []string) (*models.User, int, error) {
+	ids, err := parseIDs(args)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	u, err := findUser(ctx, env.Exec, ids[0])
+	if err != nil {
+		return nil, 0, err
+	}
+	exists, err := models.MovieExists(ctx, env.Exec, ids[1])
+	if err != nil {
+		return nil, 0, err
+	}
+	if !exists {
+		return nil, 0, notFound("movie", ids[1])
+	}
+
+	return u, ids[1], nil
+}
+
+func favoritesAdd(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, env *Env, args []string) error {
+		u, movieID, err := favoriteArgs(ctx, env, args)
+		if err != nil {
+			return err
+		}
+
+		added, err := u.AddFavorite(ctx, env.Exec, movieID)
+		if err != nil {
+			return err
+		}
+		if !added {
+			fmt.Fprintf(env.Out, "movie %d is already a favorite of user %d\n", movieID, u.ID)
+			return nil
+		}
+		fmt.Fprintf(env.Out, "added movie %d to the favorites of user %d\n", movieID, u.ID)
+
+		return nil
+	}
+}
+
+func favoritesRemove(fs *flag.FlagSet) runFunc {
+	yes := fs.Bool("yes", false, "Remove without asking for confirmation")
+
+	return func(ctx context.Context, env *Env, args []string) error {
+		u, movieID, err := favoriteArgs(ctx, env, args)
+		if err != nil {
+			return err
+		}
+
+		if err := confirm(env, *yes, fmt.Sprintf("Remove movie %d from the favorites of user %d (%s)?", movieID, u.ID, u.Name)); err != nil {
+			return err
+		}
+
+		removed, err := u.RemoveFavorite(ctx, env.Exec, movieID)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			fmt.Fprintf(env.Out, "movie %d was not a favorite of user %d\n", movieID, u.ID)
+			return nil
+		}
+		fmt.Fprintf(env.Out, "removed movie %d from the favorites of user %d\n", movieID, u.ID)
+
+		return nil
+	}
+}
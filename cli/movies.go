@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"sqlboiler-project/dto"
+	"sqlboiler-project/models"
+)
+
+func init() {
+	commands["movies list"] = spec{usage: "[-where filter]... [-limit n] [-o table|json|csv]", maxArgs: 0, setup: moviesList}
+	commands["movies get"] = spec{usage: "[-o table|json|csv] id", minArgs: 1, maxArgs: 1, setup: moviesGet}
+}
+
+func moviesList(fs *flag.FlagSet) runFunc {
+	var where whereFlag
+	fs.Var(&where, "where", whereHelp)
+	limit := fs.Int("limit", defaultLimit, "Maximum number of rows; 0 for all")
+	format := formatFlag(fs)
+
+	return func(ctx context.Context, env *Env, _ []string) error {
+		mods, err := where.mods(models.TableNames.Movies, models.Movie{})
+		if err != nil {
+			return err
+		}
+		limitMod, err := limitMods(*limit)
+		if err != nil {
+			return err
+		}
+		mods = append(append(mods, limitMod...), qm.OrderBy(models.MovieTableColumns.ID+" ASC"))
+
+		movies, err := models.Movies(mods...).All(ctx, env.Exec)
+		if err != nil {
+			return err
+		}
+
+		return write(env.Out, *format, dto.NewMoviePublics(movies))
+	}
+}
+
+func moviesGet(fs *flag.FlagSet) runFunc {
+	format := formatFlag(fs)
+
+	return func(ctx context.Context, env *Env, args []string) error {
+		id, err := parseID(args[0])
+		if err != nil {
+			return err
+		}
+
+		m, err := models.FindMovie(ctx, env.Exec, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return notFound("movie", id)
+		}
+		if err != nil {
+			return err
+		}
+
+		return write(env.Out, *format, dto.NewMoviePublic(m))
+	}
+}
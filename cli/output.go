@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/friendsofgo/errors"
+
+	"sqlboiler-project/dto"
+)
+
+// 出力形式
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// formatFlag は -output と短縮形の -o を定義する
+func formatFlag(fs *flag.FlagSet) *string {
+	f := new(string)
+	fs.StringVar(f, "output", formatTable, "Output format: table, json or csv")
+	fs.StringVar(f, "o", formatTable, "Shorthand for -output")
+	return f
+}
+
+// write は dto の値（構造体かそのスライス）を format で w に書く。
+// 表と CSV では入れ子の値を "movie.title" のような列にし、スライスの値は省く。
+func write(w io.Writer, format string, v interface{}) error {
+	switch format {
+	case formatJSON:
+		b, err := dto.Marshal(v, dto.Options{})
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, b, "", "  "); err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+		_, err = buf.WriteTo(w)
+		return err
+	case formatTable, formatCSV:
+	default:
+		return usageErrorf("unknown output format %q", format)
+	}
+
+	// 行が無くても見出しを書けるよう、見出しは要素の型のゼロ値から作る
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	header, _, err := flatten(reflect.Zero(t).Interface())
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		rv = reflect.ValueOf([]interface{}{v})
+	}
+	for i := 0; i < rv.Len(); i++ {
+		_, row, err := flatten(rv.Index(i).Interface())
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+	}
+
+	if format == formatCSV {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for i, h := range header {
+		header[i] = strings.ToUpper(h)
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// flatten は dto の値 1 つを列名と値の文字列にする
+func flatten(v interface{}) ([]string, []string, error) {
+	projected, err := dto.Project(v, dto.Options{})
+	if err != nil {
+		return nil, nil, err
+	}
+	obj, ok := projected.(dto.Object)
+	if !ok {
+		return nil, nil, errors.Errorf("cli: %T is not an object", v)
+	}
+
+	var keys, values []string
+	var walk func(prefix string, o dto.Object)
+	walk = func(prefix string, o dto.Object) {
+		for _, m := range o {
+			switch mv := m.Value.(type) {
+			case dto.Object:
+				walk(prefix+m.Key+".", mv)
+			case []interface{}:
+				// 入れ子の一覧は 1 行に収まらないので表と CSV には出さない
+			default:
+				keys = append(keys, prefix+m.Key)
+				values = append(values, formatValue(mv))
+			}
+		}
+	}
+	walk("", obj)
+
+	return keys, values, nil
+}
+
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.Format(time.RFC3339)
+	}
+
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"sqlboiler-project/dto"
+	"sqlboiler-project/models"
+)
+
+func init() {
+	commands["users list"] = spec{usage: "[-where filter]... [-limit n] [-o table|json|csv]", maxArgs: 0, setup: usersList}
+	commands["users get"] = spec{usage: "[-o table|json|csv] id", minArgs: 1, maxArgs: 1, setup: usersGet}
+}
+
+func usersList(fs *flag.FlagSet) runFunc {
+	var where whereFlag
+	fs.Var(&where, "where", whereHelp)
+	limit := fs.Int("limit", defaultLimit, "Maximum number of rows; 0 for all")
+	format := formatFlag(fs)
+
+	return func(ctx context.Context, env *Env, _ []string) error {
+		mods, err := where.mods(models.TableNames.Users, models.User{})
+		if err != nil {
+			return err
+		}
+		limitMod, err := limitMods(*limit)
+		if err != nil {
+			return err
+		}
+		mods = append(append(mods, limitMod...), qm.OrderBy(models.UserTableColumns.ID+" ASC"))
+
+		users, err := models.Users(mods...).All(ctx, env.Exec)
+		if err != nil {
+			return err
+		}
+
+		return write(env.Out, *format, dto.NewUserPublics(users))
+	}
+}
+
+func usersGet(fs *flag.FlagSet) runFunc {
+	format := formatFlag(fs)
+
+	return func(ctx context.Context, env *Env, args []string) error {
+		id, err := parseID(args[0])
+		if err != nil {
+			return err
+		}
+
+		u, err := findUser(ctx, env.Exec, id)
+		if err != nil {
+			return err
+		}
+
+		return write(env.Out, *format, dto.NewUserPublic(u))
+	}
+}
+
+// findUser はユーザーを読む。無ければ "user N not found" のエラーを返す。
+func findUser(ctx context.Context, exec boil.ContextExecutor, id int) (*models.User, error) {
+	u, err := models.FindUser(ctx, exec, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, notFound("user", id)
+	}
+
+	return u, err
+}
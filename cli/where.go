@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"sqlboiler-project/models"
+)
+
+// whereFlag は繰り返し指定できる -where。指定した条件はすべて AND で結ぶ。
+type whereFlag []string
+
+func (w *whereFlag) String() string { return strings.Join(*w, " AND ") }
+
+func (w *whereFlag) Set(s string) error {
+	*w = append(*w, s)
+	return nil
+}
+
+const whereHelp = "Filter such as 'published_year>=2000', 'title~go' (case-insensitive contains) or 'author_id is null'; repeatable"
+
+var (
+	rgxWhere     = regexp.MustCompile(`^\s*(\w+)\s*(>=|<=|!=|=|<|>|~)\s*(.*?)\s*$`)
+	rgxWhereNull = regexp.MustCompile(`(?i)^\s*(\w+)\s+is\s+(not\s+)?null\s*$`)
+)
+
+// columnTypes は生成されたモデルの構造体の boil タグからカラム名と Go の型を返す
+func columnTypes(model interface{}) map[string]reflect.Type {
+	t := reflect.TypeOf(model)
+	cols := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("boil"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		cols[name] = f.Type
+	}
+
+	return cols
+}
+
+// mods は条件を table のクエリ修飾子にする。カラムと値の型は model（生成されたモデルの構造体）で検証する。
+func (w whereFlag) mods(table string, model interface{}) ([]qm.QueryMod, error) {
+	cols := columnTypes(model)
+
+	var mods []qm.QueryMod
+	for _, expr := range w {
+		if m := rgxWhereNull.FindStringSubmatch(expr); m != nil {
+			t, ok := cols[m[1]]
+			if !ok {
+				return nil, usageErrorf("where: unknown column %q in %q", m[1], expr)
+			}
+			if !strings.HasPrefix(t.PkgPath(), "github.com/volatiletech/null") {
+				return nil, usageErrorf("where: column %q is not nullable", m[1])
+			}
+			op := "IS NULL"
+			if m[2] != "" {
+				op = "IS NOT NULL"
+			}
+			mods = append(mods, qm.Where(fmt.Sprintf("%q.%q %s", table, m[1], op)))
+			continue
+		}
+
+		m := rgxWhere.FindStringSubmatch(expr)
+		if m == nil {
+			return nil, usageErrorf("where: invalid filter %q", expr)
+		}
+		col, op, raw := m[1], m[2], m[3]
+		t, ok := cols[col]
+		if !ok {
+			return nil, usageErrorf("where: unknown column %q in %q", col, expr)
+		}
+
+		if op == "~" {
+			if t != reflect.TypeOf("") && t != reflect.TypeOf(null.String{}) {
+				return nil, usageErrorf("where: ~ needs a text column, got %q", col)
+			}
+			mods = append(mods, qm.Where(fmt.Sprintf("%q.%q ILIKE ?", table, col), models.ContainsPattern(raw)))
+			continue
+		}
+
+		v, err := parseValue(t, raw)
+		if err != nil {
+			return nil, usageErrorf("where: %s: %v", col, err)
+		}
+		mods = append(mods, qm.Where(fmt.Sprintf("%q.%q %s ?", table, col, op), v))
+	}
+
+	return mods, nil
+}
+
+// parseValue は raw をカラムの型 t の値にする
+func parseValue(t reflect.Type, raw string) (interface{}, error) {
+	switch t {
+	case reflect.TypeOf(""), reflect.TypeOf(null.String{}):
+		return raw, nil
+	case reflect.TypeOf(0), reflect.TypeOf(null.Int{}):
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, errors.Errorf("invalid integer %q", raw)
+		}
+		return n, nil
+	case reflect.TypeOf(false), reflect.TypeOf(null.Bool{}):
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.Errorf("invalid boolean %q", raw)
+		}
+		return b, nil
+	case reflect.TypeOf(time.Time{}), reflect.TypeOf(null.Time{}):
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if v, err := time.Parse(layout, raw); err == nil {
+				return v, nil
+			}
+		}
+		return nil, errors.Errorf("invalid time %q (want RFC 3339 or YYYY-MM-DD)", raw)
+	}
+
+	return nil, errors.Errorf("unsupported column type %s", t)
+}
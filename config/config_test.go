@@ -0,0 +1,200 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testConfig = `
+[psql]
+  dbname = "file_db"
+  host   = "file-host"
+  port   = 5433
+  user   = "file_user"
+  pass   = "file_pass"
+  sslmode = "disable"
+  blacklist = ["schema_migrations"]
+
+[app]
+  debug = false
+  search_limit = 10
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "sqlboiler.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func parseFlags(t *testing.T, args ...string) *Flags {
+	t.Helper()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	f := RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+
+	return f
+}
+
+func TestLoadFromFile(t *testing.T) {
+	path := writeConfig(t, testConfig)
+
+	cfg, err := Load(parseFlags(t, "-config", path))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "user=file_user password=file_pass dbname=file_db host=file-host port=5433 sslmode=disable"
+	if got := cfg.DSN(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if cfg.App.SearchLimit != 10 || cfg.App.Debug {
+		t.Errorf("unexpected app settings %#v", cfg.App)
+	}
+	if len(cfg.PSQL.Blacklist) != 1 || cfg.PSQL.Blacklist[0] != "schema_migrations" {
+		t.Errorf("unexpected blacklist %v", cfg.PSQL.Blacklist)
+	}
+	if cfg.PSQL.Role != "app_tenant" || cfg.App.TenantID != 0 {
+		t.Errorf("want the RLS role and tenant 0 by default, got %q and %d", cfg.PSQL.Role, cfg.App.TenantID)
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	path := writeConfig(t, testConfig)
+	t.Setenv("PSQL_HOST", "env-host")
+	t.Setenv("PSQL_USER", "env_user")
+	t.Setenv("APP_SEARCH_LIMIT", "20")
+
+	cfg, err := Load(parseFlags(t, "-config", path, "-psql.host", "flag-host", "-app.debug", "-psql.role=", "-app.tenant-id", "7"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.PSQL.Host != "flag-host" {
+		t.Errorf("flag should win over env and file, got %q", cfg.PSQL.Host)
+	}
+	if cfg.PSQL.User != "env_user" {
+		t.Errorf("env should win over file, got %q", cfg.PSQL.User)
+	}
+	if cfg.PSQL.DBName != "file_db" {
+		t.Errorf("file should win over defaults, got %q", cfg.PSQL.DBName)
+	}
+	if cfg.App.SearchLimit != 20 || !cfg.App.Debug || cfg.App.TenantID != 7 {
+		t.Errorf("unexpected app settings %#v", cfg.App)
+	}
+	if cfg.PSQL.Role != "" {
+		t.Errorf("an empty -psql.role should keep the login user, got %q", cfg.PSQL.Role)
+	}
+}
+
+func TestDatabaseURL(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://u:p@db.example.com:5432/app?sslmode=disable")
+
+	// [psql] が無くても DATABASE_URL だけで動く
+	f := parseFlags(t, "-config", writeConfig(t, "[app]\n  debug = true\n"))
+
+	cfg, err := Load(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.DSN(); got != "postgres://u:p@db.example.com:5432/app?sslmode=disable" {
+		t.Errorf("unexpected dsn %q", got)
+	}
+
+	t.Setenv("DATABASE_URL", "mysql://u:p@db.example.com/app")
+	if _, err := Load(f); err == nil || !strings.Contains(err.Error(), "postgres scheme") {
+		t.Errorf("expected a scheme error, got %v", err)
+	}
+}
+
+func TestPassFile(t *testing.T) {
+	path := writeConfig(t, testConfig)
+	secret := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(secret, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(parseFlags(t, "-config", path, "-psql.pass-file", secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.PSQL.Pass != "s3cret" {
+		t.Errorf("want password from file, got %q", cfg.PSQL.Pass)
+	}
+
+	if _, err := Load(parseFlags(t, "-config", path, "-psql.pass-file", secret+".missing")); err == nil {
+		t.Error("expected an error for a missing password file")
+	}
+}
+
+func TestValidation(t *testing.T) {
+	path := writeConfig(t, "[psql]\n  dbname = \"x\"\n")
+
+	_, err := Load(parseFlags(t, "-config", path))
+	if err == nil {
+		t.Fatal("expected a validation error")
+	}
+	for _, key := range []string{"psql.user", "psql.host"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("expected %s in %q", key, err)
+		}
+	}
+}
+
+func TestWatcherReload(t *testing.T) {
+	path := writeConfig(t, testConfig)
+
+	// ファイル監視は始めずに Reload を直接呼ぶ
+	v, err := newViper(parseFlags(t, "-config", path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := build(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := &Watcher{v: v, cfg: cfg}
+
+	var got []App
+	w.OnChange(func(a App) { got = append(got, a) })
+
+	changed := strings.Replace(testConfig, "search_limit = 10", "search_limit = 30", 1)
+	changed = strings.Replace(changed, `host   = "file-host"`, `host   = "other-host"`, 1)
+	if err := os.WriteFile(path, []byte(changed), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.v.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	w.Reload()
+
+	if w.App().SearchLimit != 30 || len(got) != 1 || got[0].SearchLimit != 30 {
+		t.Errorf("app settings were not reloaded: %#v %#v", w.App(), got)
+	}
+	if w.Config().PSQL.Host != "file-host" {
+		t.Errorf("connection settings must not change on reload, got %q", w.Config().PSQL.Host)
+	}
+
+	// 不正な値では以前の設定を使い続ける
+	if err := os.WriteFile(path, []byte(strings.Replace(changed, "search_limit = 30", "search_limit = 0", 1)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.v.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	w.Reload()
+
+	if w.App().SearchLimit != 30 || len(got) != 1 {
+		t.Errorf("invalid settings should be ignored: %#v", w.App())
+	}
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"log"
+	"reflect"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// Watcher は設定ファイルの変更を検知して [app] の設定を差し替える。
+// 接続設定の変更は再起動するまで反映しない（既存の接続プールと食い違うため）。
+type Watcher struct {
+	v *viper.Viper
+
+	mu        sync.RWMutex
+	cfg       *Config
+	listeners []func(App)
+}
+
+// Watch は Load と同じ手順で設定を読み込み、設定ファイルの監視を始める
+func Watch(f *Flags) (*Watcher, error) {
+	v, err := newViper(f)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := build(v)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{v: v, cfg: cfg}
+	w.start()
+
+	return w, nil
+}
+
+// start は設定ファイルの監視を始める。ファイルを読んでいなければ何もしない。
+func (w *Watcher) start() {
+	if len(w.v.ConfigFileUsed()) == 0 {
+		return
+	}
+
+	w.v.OnConfigChange(func(fsnotify.Event) { w.Reload() })
+	w.v.WatchConfig()
+}
+
+// Config は現在の設定を返す
+func (w *Watcher) Config() Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return *w.cfg
+}
+
+// App は現在の [app] の設定を返す
+func (w *Watcher) App() App {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.cfg.App
+}
+
+// OnChange は [app] の設定が変わったときに呼ぶ関数を登録する
+func (w *Watcher) OnChange(fn func(App)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.listeners = append(w.listeners, fn)
+}
+
+// Reload は読み込み済みの設定ファイルの内容から [app] を作り直す。
+// 値が不正な場合は以前の設定を使い続ける。
+func (w *Watcher) Reload() {
+	next, err := build(w.v)
+	if err != nil {
+		log.Printf("設定再読み込みエラー（以前の設定を使います）: %v\n", err)
+		return
+	}
+
+	w.mu.Lock()
+	prev := w.cfg
+	if !reflect.DeepEqual(prev.PSQL, next.PSQL) || prev.DatabaseURL != next.DatabaseURL {
+		log.Println("接続設定の変更は再起動するまで反映されません")
+	}
+	changed := prev.App != next.App
+	w.cfg = &Config{PSQL: prev.PSQL, DatabaseURL: prev.DatabaseURL, App: next.App}
+	listeners := append([]func(App){}, w.listeners...)
+	w.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	for _, fn := range listeners {
+		fn(next.App)
+	}
+}
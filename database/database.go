@@ -0,0 +1,134 @@
+// Package database は *sql.DB の起動と終了をまとめる。
+// 接続プールの設定、起動時の接続待ち、ヘルスチェック、シグナルでの終了を扱う。
+package database
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/friendsofgo/errors"
+
+	"sqlboiler-project/config"
+	"sqlboiler-project/tenant"
+)
+
+// Options は接続プールと起動時の接続待ちの設定
+type Options struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// ReadyTimeout は起動時にデータベースが応答するまで待つ時間。0 なら待たずに 1 回だけ試す。
+	ReadyTimeout time.Duration
+	// RetryInterval は接続を再試行する最初の間隔。失敗するたびに倍にする（上限 5 秒）。
+	RetryInterval time.Duration
+}
+
+// 再試行の間隔の既定値と上限
+const (
+	defaultRetryInterval = 200 * time.Millisecond
+	maxRetryInterval     = 5 * time.Second
+)
+
+// OptionsFrom は設定から Options を作る
+func OptionsFrom(c config.PSQL) Options {
+	return Options{
+		MaxOpenConns:    c.MaxOpenConns,
+		MaxIdleConns:    c.MaxIdleConns,
+		ConnMaxLifetime: c.ConnMaxLifetime,
+		ConnMaxIdleTime: c.ConnMaxIdleTime,
+		ReadyTimeout:    c.ReadyTimeout,
+		RetryInterval:   defaultRetryInterval,
+	}
+}
+
+// Open は設定の接続先に接続し、プールを設定してデータベースが応答するまで待つ。
+// 接続は tenant.Open で psql.role に切り替えるので、クエリには tenant.WithTenant でテナントを載せた ctx を渡す
+// （載っていなければ RLS を掛けたテーブルの行は見えない）。
+func Open(ctx context.Context, cfg *config.Config) (*sql.DB, error) {
+	db, err := tenant.Open(cfg.DSN(), cfg.PSQL.Role)
+	if err != nil {
+		return nil, errors.Wrap(err, "database: unable to open")
+	}
+
+	opts := OptionsFrom(cfg.PSQL)
+	Configure(db, opts)
+
+	if err = WaitReady(ctx, db, opts); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Configure は接続プールを設定する
+func Configure(db *sql.DB, opts Options) {
+	db.SetMaxOpenConns(opts.MaxOpenConns)
+	db.SetMaxIdleConns(opts.MaxIdleConns)
+	db.SetConnMaxLifetime(opts.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(opts.ConnMaxIdleTime)
+}
+
+// WaitReady はデータベースが応答するまで Ping を繰り返す。
+// docker-compose up 直後のように、サーバーの起動がアプリケーションより遅れる場合に使う。
+func WaitReady(ctx context.Context, db *sql.DB, opts Options) error {
+	if opts.ReadyTimeout <= 0 {
+		return errors.Wrap(db.PingContext(ctx), "database: not ready")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.ReadyTimeout)
+	defer cancel()
+
+	interval := opts.RetryInterval
+	if interval <= 0 {
+		interval = defaultRetryInterval
+	}
+	for attempt := 1; ; attempt++ {
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(err, "database: not ready after %s (%d attempts)", opts.ReadyTimeout, attempt)
+		case <-time.After(interval):
+		}
+
+		log.Printf("データベース接続待機中 (%d 回目): %v\n", attempt, err)
+		if interval *= 2; interval > maxRetryInterval {
+			interval = maxRetryInterval
+		}
+	}
+}
+
+// SignalContext は SIGINT か SIGTERM を受け取るとキャンセルされる context を返す
+func SignalContext(parent context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+}
+
+// Shutdown は実行中のクエリが終わるのを最大 timeout だけ待ってからプールを閉じる。
+// 待ちきれなかった場合もプールは閉じ、エラーを返す。
+func Shutdown(db *sql.DB, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for db.Stats().InUse > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	inUse := db.Stats().InUse
+	if err := db.Close(); err != nil {
+		return errors.Wrap(err, "database: unable to close")
+	}
+	if inUse > 0 {
+		return errors.Errorf("database: closed with %d connections still in use", inUse)
+	}
+
+	return nil
+}
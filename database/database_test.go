@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flaky は最初の failures 回の接続に失敗し、以降は SELECT 1 に 1 を返すドライバ
+type flaky struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+	query    func() error
+}
+
+func (f *flaky) Connect(context.Context) (driver.Conn, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.attempts++
+	if f.attempts <= f.failures {
+		return nil, errors.New("connection refused")
+	}
+
+	return &flakyConn{f: f}, nil
+}
+
+func (f *flaky) Driver() driver.Driver { return nil }
+
+type flakyConn struct{ f *flaky }
+
+func (c *flakyConn) Prepare(query string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *flakyConn) Close() error                              { return nil }
+func (c *flakyConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+func (c *flakyConn) Ping(context.Context) error                { return nil }
+
+func (c *flakyConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.f.query != nil {
+		if err := c.f.query(); err != nil {
+			return nil, err
+		}
+	}
+	return &oneRow{}, nil
+}
+
+type oneRow struct{ done bool }
+
+func (r *oneRow) Columns() []string { return []string{"?column?"} }
+func (r *oneRow) Close() error      { return nil }
+
+func (r *oneRow) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(1)
+	return nil
+}
+
+func openFlaky(t *testing.T, f *flaky) *sql.DB {
+	t.Helper()
+
+	db := sql.OpenDB(f)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+func TestWaitReadyRetries(t *testing.T) {
+	t.Parallel()
+
+	f := &flaky{failures: 3}
+	db := openFlaky(t, f)
+
+	err := WaitReady(context.Background(), db, Options{ReadyTimeout: 5 * time.Second, RetryInterval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.attempts != 4 {
+		t.Errorf("expected 4 connection attempts, got %d", f.attempts)
+	}
+}
+
+func TestWaitReadyTimeout(t *testing.T) {
+	t.Parallel()
+
+	db := openFlaky(t, &flaky{failures: 1 << 30})
+
+	err := WaitReady(context.Background(), db, Options{ReadyTimeout: 50 * time.Millisecond, RetryInterval: time.Millisecond})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	// ReadyTimeout が 0 なら 1 回だけ試す
+	f := &flaky{failures: 1 << 30}
+	if err = WaitReady(context.Background(), openFlaky(t, f), Options{}); err == nil || f.attempts != 1 {
+		t.Errorf("expected a single failed attempt, got %d (%v)", f.attempts, err)
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	t.Parallel()
+
+	db := openFlaky(t, &flaky{})
+	Configure(db, Options{MaxOpenConns: 7, MaxIdleConns: 3})
+
+	if got := Stats(db).MaxOpenConnections; got != 7 {
+		t.Errorf("expected max open connections 7, got %d", got)
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	t.Parallel()
+
+	f := &flaky{}
+	db := openFlaky(t, f)
+
+	if h := CheckHealth(context.Background(), db, time.Second); h.Status != "ok" || h.Pool.OpenConnections != 1 {
+		t.Errorf("unexpected health %#v", h)
+	}
+
+	f.query = func() error { return errors.New("boom") }
+	rec := httptest.NewRecorder()
+	HealthHandler(db, time.Second).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", rec.Code)
+	}
+
+	var h Health
+	if err := json.NewDecoder(rec.Body).Decode(&h); err != nil {
+		t.Fatal(err)
+	}
+	if h.Status != "unavailable" || len(h.Error) == 0 {
+		t.Errorf("unexpected health %#v", h)
+	}
+}
+
+func TestShutdownWaitsForConnections(t *testing.T) {
+	t.Parallel()
+
+	db := sql.OpenDB(&flaky{})
+
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = conn.Close()
+	}()
+
+	if err = Shutdown(db, time.Second); err != nil {
+		t.Error(err)
+	}
+
+	// 使用中の接続が返ってこなければエラーにする
+	db = sql.OpenDB(&flaky{})
+	if _, err = db.Conn(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err = Shutdown(db, 20*time.Millisecond); err == nil {
+		t.Error("expected an error while a connection is in use")
+	}
+}
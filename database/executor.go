@@ -0,0 +1,369 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/lib/pq"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"sqlboiler-project/config"
+)
+
+// Op は文の種類。種類ごとにタイムアウトを変える。
+type Op int
+
+// 文の種類
+const (
+	OpDefault Op = iota
+	OpRead
+	OpWrite
+	OpBulkWrite
+)
+
+func (o Op) String() string {
+	switch o {
+	case OpRead:
+		return "read"
+	case OpWrite:
+		return "write"
+	case OpBulkWrite:
+		return "bulk write"
+	default:
+		return "default"
+	}
+}
+
+// Timeouts は種類ごとのタイムアウト（設定の [app.timeouts]）
+type Timeouts = config.Timeouts
+
+// PostgreSQL がタイムアウトで文を中断したときのエラーコード
+const (
+	codeQueryCanceled    = "57014" // statement_timeout
+	codeLockNotAvailable = "55P03" // lock_timeout
+)
+
+// TimeoutError はタイムアウトで中断された文のエラー。
+// モデルのメソッドはエラーを包んで返すので errors.As で取り出す。
+type TimeoutError struct {
+	Op      Op
+	Timeout time.Duration
+	Query   string
+	Err     error
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Timeout == 0 {
+		return fmt.Sprintf("database: %s timed out: %v", e.Op, e.Err)
+	}
+	return fmt.Sprintf("database: %s timed out after %s: %v", e.Op, e.Timeout, e.Err)
+}
+
+// Unwrap は元のエラーを返す
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// CanceledError は呼び出し側が context をキャンセルして中断された文のエラー。
+// lib/pq はキャンセルした文にも statement_timeout と同じ 57014 を返すので、context の状態で区別する。
+// errors.Is(err, context.Canceled) で判定できる。
+type CanceledError struct {
+	Op    Op
+	Query string
+	Err   error
+}
+
+func (e *CanceledError) Error() string {
+	return fmt.Sprintf("database: %s canceled: %v", e.Op, e.Err)
+}
+
+// Unwrap は context.Canceled と元のエラーを返す
+func (e *CanceledError) Unwrap() []error {
+	return []error{context.Canceled, e.Err}
+}
+
+// IsTimeout は err がタイムアウトによるものかを返す。
+// TimeoutError のほか、context のデッドライン超過と statement_timeout / lock_timeout も含む。
+// 呼び出し側のキャンセル（context.Canceled）はタイムアウトではない。
+func IsTimeout(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var te *TimeoutError
+	if errors.As(err, &te) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == codeQueryCanceled || pqErr.Code == codeLockNotAvailable
+	}
+
+	return false
+}
+
+type opKey struct{}
+
+// WithOp は ctx で実行する文の種類を指定する。
+// SQL からの判定（下記 classify）より優先されるので、判定できない一括更新などに使う。
+func WithOp(ctx context.Context, op Op) context.Context {
+	return context.WithValue(ctx, opKey{}, op)
+}
+
+var (
+	// 生成コードが主キーで 1 行だけを更新・削除するときの WHERE 句（複合主キーを含む）
+	rgxSingleRowWhere = regexp.MustCompile(`(?i)\bWHERE\s+"\w+"\s*=\s*\$\d+(\s+AND\s+"\w+"\s*=\s*\$\d+)*\s*;?\s*$`)
+	rgxFirstWord      = regexp.MustCompile(`^\s*(\w+)`)
+	rgxModifying      = regexp.MustCompile(`(?i)\b(INSERT|UPDATE|DELETE)\b`)
+)
+
+// classify は SQL の先頭のキーワードから文の種類を判定する。
+// 主キー以外の条件を持つ UPDATE / DELETE（UpdateAll や DeleteAll）は一括更新とみなす。
+// WITH の中に INSERT / UPDATE / DELETE があれば、何行変わるか分からないので一括更新とみなす。
+func classify(query string) Op {
+	m := rgxFirstWord.FindStringSubmatch(query)
+	if m == nil {
+		return OpDefault
+	}
+
+	switch strings.ToUpper(m[1]) {
+	case "WITH":
+		if rgxModifying.MatchString(query) {
+			return OpBulkWrite
+		}
+		return OpRead
+	case "SELECT", "EXPLAIN":
+		return OpRead
+	case "INSERT":
+		return OpWrite
+	case "UPDATE", "DELETE":
+		if rgxSingleRowWhere.MatchString(query) {
+			return OpWrite
+		}
+		return OpBulkWrite
+	default:
+		return OpDefault
+	}
+}
+
+// Executor は文ごとにタイムアウトを付けて実行する boil.ContextExecutor。
+// タイムアウトは SetTimeouts で実行中に差し替えられる（設定の再読み込み用）。
+type Executor struct {
+	exec     boil.ContextExecutor
+	timeouts atomic.Pointer[Timeouts]
+}
+
+// NewExecutor は exec をタイムアウト付きで包む
+func NewExecutor(exec boil.ContextExecutor, t Timeouts) *Executor {
+	e := &Executor{exec: exec}
+	e.SetTimeouts(t)
+
+	return e
+}
+
+// SetTimeouts はタイムアウトを差し替える
+func (e *Executor) SetTimeouts(t Timeouts) {
+	e.timeouts.Store(&t)
+}
+
+// Timeouts は現在のタイムアウトを返す
+func (e *Executor) Timeouts() Timeouts {
+	return *e.timeouts.Load()
+}
+
+func (e *Executor) timeout(op Op) time.Duration {
+	t := e.Timeouts()
+
+	var d time.Duration
+	switch op {
+	case OpRead:
+		d = t.Read
+	case OpWrite:
+		d = t.Write
+	case OpBulkWrite:
+		d = t.BulkWrite
+	}
+	if d == 0 {
+		d = t.Default
+	}
+
+	return d
+}
+
+// deadline は文の種類を決め、タイムアウトを付けた context を返す。
+// 呼び出し側がもっと短いデッドラインを付けていればそちらが効く。
+func (e *Executor) deadline(ctx context.Context, query string) (context.Context, context.CancelFunc, Op, time.Duration) {
+	op, ok := ctx.Value(opKey{}).(Op)
+	if !ok {
+		op = classify(query)
+	}
+
+	d := e.timeout(op)
+	if d <= 0 {
+		return ctx, func() {}, op, 0
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d)
+	return ctx, cancel, op, d
+}
+
+// wrapErr は ctx のキャンセルによるエラーを CanceledError に、タイムアウトによるエラーを TimeoutError にする
+func wrapErr(ctx context.Context, err error, op Op, d time.Duration, query string) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(ctx.Err(), context.Canceled) {
+		if errors.Is(err, context.Canceled) {
+			return err
+		}
+		return &CanceledError{Op: op, Query: query, Err: err}
+	}
+	if !IsTimeout(err) {
+		return err
+	}
+
+	return &TimeoutError{Op: op, Timeout: d, Query: query, Err: err}
+}
+
+// Exec は context なしで実行する（タイムアウトは付けない）
+func (e *Executor) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return e.exec.Exec(query, args...)
+}
+
+// Query は context なしで実行する（タイムアウトは付けない）
+func (e *Executor) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return e.exec.Query(query, args...)
+}
+
+// QueryRow は context なしで実行する（タイムアウトは付けない）
+func (e *Executor) QueryRow(query string, args ...interface{}) *sql.Row {
+	return e.exec.QueryRow(query, args...)
+}
+
+// ExecContext はタイムアウトを付けて実行する
+func (e *Executor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, cancel, op, d := e.deadline(ctx, query)
+	defer cancel()
+
+	res, err := e.exec.ExecContext(ctx, query, args...)
+	return res, wrapErr(ctx, err, op, d, query)
+}
+
+// QueryContext はタイムアウトを付けて実行する。
+// 行の読み出し中に context をキャンセルすると結果が閉じられるので、キャンセルはタイムアウトに任せる。
+func (e *Executor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, cancel, op, d := e.deadline(ctx, query)
+	if d > 0 {
+		// デッドラインを過ぎて ctx が終わったら解放する
+		context.AfterFunc(ctx, cancel)
+	}
+
+	rows, err := e.exec.QueryContext(ctx, query, args...)
+	if err != nil {
+		cancel()
+		return nil, wrapErr(ctx, err, op, d, query)
+	}
+
+	return rows, nil
+}
+
+// QueryRowContext はタイムアウトを付けて実行する。キャンセルの扱いは QueryContext と同じ。
+// 実行や読み出しのエラーは Scan で TimeoutError / CanceledError として返る（row.go）。
+func (e *Executor) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, cancel, op, d := e.deadline(ctx, query)
+	if d > 0 {
+		// デッドラインを過ぎて ctx が終わったら解放する
+		context.AfterFunc(ctx, cancel)
+	}
+
+	rows, err := e.exec.QueryContext(ctx, query, args...)
+	if err != nil {
+		cancel()
+		return newRow(ctx, nil, wrapErr(ctx, err, op, d, query))
+	}
+
+	return newRow(ctx, &row{ctx: ctx, rows: rows, op: op, d: d, query: query}, nil)
+}
+
+// BeginTx はトランザクションを開始し、statement_timeout と lock_timeout を SET LOCAL する。
+// 返す Tx の文にも種類ごとのタイムアウトが付く。
+func (e *Executor) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	beginner, ok := e.exec.(boil.ContextBeginner)
+	if !ok {
+		return nil, errors.New("database: executor cannot begin transactions")
+	}
+
+	tx, err := beginner.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "database: unable to begin transaction")
+	}
+
+	t := e.Timeouts()
+	if t.Statement > 0 || t.Lock > 0 {
+		_, err = tx.ExecContext(ctx, "SELECT set_config('statement_timeout', $1, true), set_config('lock_timeout', $2, true)",
+			millis(t.Statement), millis(t.Lock))
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, errors.Wrap(err, "database: unable to set transaction timeouts")
+		}
+	}
+
+	return &Tx{Executor: NewExecutor(tx, t), tx: tx}, nil
+}
+
+// millis は PostgreSQL の時間設定の値（ミリ秒、0 は無効）にする
+func millis(d time.Duration) string {
+	return fmt.Sprintf("%d", d.Milliseconds())
+}
+
+// Tx はタイムアウト付きのトランザクション。boil.ContextTransactor を満たす。
+type Tx struct {
+	*Executor
+	tx *sql.Tx
+}
+
+// Commit はトランザクションをコミットする
+func (t *Tx) Commit() error {
+	return t.tx.Commit()
+}
+
+// Rollback はトランザクションをロールバックする
+func (t *Tx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// InTx は fn を 1 つのトランザクションで実行し、fn がエラーを返さなければコミットする。
+// exec が Executor か boil.ContextBeginner（*sql.DB）ならトランザクションを開始する。
+// それ以外（Tx や *sql.Tx など、既にトランザクションの中）なら exec をそのまま fn に渡す。
+func InTx(ctx context.Context, exec boil.ContextExecutor, fn func(exec boil.ContextExecutor) error) error {
+	var tx boil.ContextTransactor
+	var err error
+	switch e := exec.(type) {
+	case *Tx:
+		return fn(e)
+	case *Executor:
+		if tx, err = e.BeginTx(ctx, nil); err != nil {
+			return err
+		}
+	case boil.ContextBeginner:
+		if tx, err = e.BeginTx(ctx, nil); err != nil {
+			return errors.Wrap(err, "database: unable to begin transaction")
+		}
+	default:
+		return fn(exec)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return errors.Wrap(tx.Commit(), "database: unable to commit transaction")
+}
@@ -0,0 +1,348 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/lib/pq"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"sqlboiler-project/models"
+)
+
+// sleeper は "pg_sleep" を含む文だけ context が終わるまで返さないドライバ。
+// pqErrors なら lib/pq と同じく、中断した文に context のエラーではなく 57014 を返す。
+type sleeper struct {
+	pqErrors bool
+
+	mu    sync.Mutex
+	stmts []string
+}
+
+func (s *sleeper) Connect(context.Context) (driver.Conn, error) { return &sleeperConn{s: s}, nil }
+func (s *sleeper) Driver() driver.Driver                        { return nil }
+
+func (s *sleeper) take() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ret := s.stmts
+	s.stmts = nil
+	return ret
+}
+
+type sleeperConn struct{ s *sleeper }
+
+func (c *sleeperConn) Prepare(query string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *sleeperConn) Close() error                              { return nil }
+func (c *sleeperConn) Begin() (driver.Tx, error)                 { return c, nil }
+func (c *sleeperConn) Commit() error                             { return nil }
+func (c *sleeperConn) Rollback() error                           { return nil }
+
+func (c *sleeperConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *sleeperConn) run(ctx context.Context, query string, args []driver.NamedValue) error {
+	vals := make([]string, len(args))
+	for i, a := range args {
+		vals[i] = a.Value.(string)
+	}
+
+	c.s.mu.Lock()
+	c.s.stmts = append(c.s.stmts, strings.TrimSpace(query+" "+strings.Join(vals, " ")))
+	c.s.mu.Unlock()
+
+	if strings.Contains(query, "pg_sleep") {
+		<-ctx.Done()
+		if c.s.pqErrors {
+			return &pq.Error{Code: "57014", Message: "canceling statement due to user request"}
+		}
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (c *sleeperConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.run(ctx, query, args); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (c *sleeperConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.run(ctx, query, args); err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(query, "SELECT 42") {
+		return &answerRows{}, nil
+	}
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string              { return nil }
+func (emptyRows) Close() error                   { return nil }
+func (emptyRows) Next(dest []driver.Value) error { return io.EOF }
+
+// answerRows は 42 と "answer" の 1 行を返す
+type answerRows struct{ done bool }
+
+func (r *answerRows) Columns() []string { return []string{"n", "s"} }
+func (r *answerRows) Close() error      { return nil }
+
+func (r *answerRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0], dest[1] = int64(42), []byte("answer")
+	return nil
+}
+
+func openSleeper(t *testing.T) (*sql.DB, *sleeper) {
+	t.Helper()
+
+	s := &sleeper{}
+	db := sql.OpenDB(s)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db, s
+}
+
+func TestClassify(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]Op{
+		`SELECT "books".* FROM "books";`:                                              OpRead,
+		`  with x as (select 1) select * from x`:                                      OpRead,
+		`WITH moved AS (DELETE FROM "outbox_events" RETURNING *) SELECT * FROM moved`: OpBulkWrite,
+		`with x as (update "books" set "title" = $1 returning "id") select 1`:         OpBulkWrite,
+		`WITH x AS (SELECT "updated_at" FROM "books") SELECT * FROM x`:                OpRead,
+		`INSERT INTO "books" ("title") VALUES ($1) RETURNING "id"`:                    OpWrite,
+		`UPDATE "books" SET "title"=$1 WHERE "id"=$2`:                                 OpWrite,
+		`DELETE FROM "user_favorite_movies" WHERE "user_id"=$1 AND "movie_id"=$2`:     OpWrite,
+		`UPDATE "books" SET "title" = $1 WHERE ("published_year" < $2);`:              OpBulkWrite,
+		`DELETE FROM "books" WHERE ("id") IN (($1),($2))`:                             OpBulkWrite,
+		`DELETE FROM "books";`: OpBulkWrite,
+		`SET ROLE app_tenant`:  OpDefault,
+	}
+
+	for query, want := range tests {
+		if got := classify(query); got != want {
+			t.Errorf("%s: want %s, got %s", query, want, got)
+		}
+	}
+}
+
+func TestExecutorTimeouts(t *testing.T) {
+	t.Parallel()
+
+	db, _ := openSleeper(t)
+	exec := NewExecutor(db, Timeouts{Default: time.Second, Read: 10 * time.Millisecond, BulkWrite: time.Minute})
+	ctx := context.Background()
+
+	_, err := exec.QueryContext(ctx, "SELECT pg_sleep(10)")
+	var te *TimeoutError
+	if !errors.As(err, &te) {
+		t.Fatalf("expected a TimeoutError, got %v", err)
+	}
+	if te.Op != OpRead || te.Timeout != 10*time.Millisecond {
+		t.Errorf("unexpected timeout error %#v", te)
+	}
+
+	// 呼び出し側で種類を指定できる
+	exec.SetTimeouts(Timeouts{Read: time.Minute, BulkWrite: 10 * time.Millisecond})
+	_, err = exec.ExecContext(WithOp(ctx, OpBulkWrite), "SELECT pg_sleep(10)")
+	if !errors.As(err, &te) || te.Op != OpBulkWrite {
+		t.Errorf("expected a bulk write timeout, got %v", err)
+	}
+
+	// 行の読み出しはタイムアウトまで続けられる
+	rows, err := exec.QueryContext(ctx, "SELECT 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for rows.Next() {
+	}
+	if err = rows.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExecutorCanceled(t *testing.T) {
+	t.Parallel()
+
+	for _, pqErrors := range []bool{false, true} {
+		db, s := openSleeper(t)
+		s.pqErrors = pqErrors
+		exec := NewExecutor(db, Timeouts{Default: time.Minute})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		_, err := exec.ExecContext(ctx, "SELECT pg_sleep(10)")
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("pqErrors=%t: expected context.Canceled, got %v", pqErrors, err)
+		}
+		var te *TimeoutError
+		if errors.As(err, &te) || IsTimeout(err) {
+			t.Errorf("pqErrors=%t: cancellation reported as a timeout: %v", pqErrors, err)
+		}
+
+		var ce *CanceledError
+		if pqErrors != errors.As(err, &ce) {
+			t.Errorf("pqErrors=%t: unexpected error %#v", pqErrors, err)
+		}
+		if ce != nil && ce.Op != OpRead {
+			t.Errorf("unexpected op %s", ce.Op)
+		}
+	}
+
+	// lib/pq の 57014 でもデッドラインを過ぎたならタイムアウト
+	db, s := openSleeper(t)
+	s.pqErrors = true
+	exec := NewExecutor(db, Timeouts{Read: 10 * time.Millisecond})
+	_, err := exec.QueryContext(context.Background(), "SELECT pg_sleep(10)")
+	var te *TimeoutError
+	if !errors.As(err, &te) || errors.Is(err, context.Canceled) {
+		t.Errorf("expected a TimeoutError, got %v", err)
+	}
+}
+
+func TestExecutorQueryRow(t *testing.T) {
+	t.Parallel()
+
+	db, s := openSleeper(t)
+	exec := NewExecutor(db, Timeouts{Read: 10 * time.Millisecond})
+
+	var n int
+	err := exec.QueryRowContext(context.Background(), "SELECT pg_sleep(10)").Scan(&n)
+	var te *TimeoutError
+	if !errors.As(err, &te) || te.Op != OpRead {
+		t.Errorf("expected a read TimeoutError, got %v", err)
+	}
+
+	// 呼び出し側のキャンセルは CanceledError になる
+	s.pqErrors = true
+	exec.SetTimeouts(Timeouts{Default: time.Minute})
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	err = exec.QueryRowContext(ctx, "SELECT pg_sleep(10)").Scan(&n)
+	var ce *CanceledError
+	if !errors.As(err, &ce) || IsTimeout(err) {
+		t.Errorf("expected a CanceledError, got %v", err)
+	}
+
+	if err = exec.QueryRowContext(context.Background(), "SELECT 1").Scan(&n); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+
+	var str string
+	if err = exec.QueryRowContext(context.Background(), "SELECT 42").Scan(&n, &str); err != nil {
+		t.Fatal(err)
+	}
+	if n != 42 || str != "answer" {
+		t.Errorf("got %d %q", n, str)
+	}
+}
+
+func TestExecutorWithModels(t *testing.T) {
+	t.Parallel()
+
+	db, s := openSleeper(t)
+	exec := NewExecutor(db, Timeouts{Read: time.Second})
+
+	if _, err := models.Books().All(context.Background(), exec); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.take(); len(got) != 1 || got[0] != `SELECT "books".* FROM "books";` {
+		t.Errorf("unexpected statements %v", got)
+	}
+}
+
+func TestBeginTxSetsTimeouts(t *testing.T) {
+	t.Parallel()
+
+	db, s := openSleeper(t)
+	exec := NewExecutor(db, Timeouts{Statement: 30 * time.Second, Lock: 1500 * time.Millisecond})
+
+	tx, err := exec.BeginTx(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = models.Books().All(context.Background(), tx); err != nil {
+		t.Fatal(err)
+	}
+	if err = tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"SELECT set_config('statement_timeout', $1, true), set_config('lock_timeout', $2, true) 30000 1500",
+		`SELECT "books".* FROM "books";`,
+	}
+	if got := s.take(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("want:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestInTx(t *testing.T) {
+	t.Parallel()
+
+	db, _ := openSleeper(t)
+	exec := NewExecutor(db, Timeouts{})
+	ctx := context.Background()
+
+	var outer boil.ContextExecutor
+	err := InTx(ctx, exec, func(tx boil.ContextExecutor) error {
+		outer = tx
+		// トランザクションの中では新しく開始しない
+		return InTx(ctx, tx, func(inner boil.ContextExecutor) error {
+			if inner != tx {
+				t.Error("nested InTx should reuse the transaction")
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := outer.(*Tx); !ok {
+		t.Errorf("want a *Tx, got %T", outer)
+	}
+
+	want := errors.New("failed")
+	if err := InTx(ctx, db, func(boil.ContextExecutor) error { return want }); err != want {
+		t.Errorf("want the error from fn, got %v", err)
+	}
+}
+
+func TestIsTimeout(t *testing.T) {
+	t.Parallel()
+
+	// モデルのメソッドと同じく包まれたエラー
+	tests := map[error]bool{
+		errors.Wrap(&pq.Error{Code: "57014"}, "models: failed to assign all query results to Book slice"): true,
+		errors.Wrap(&pq.Error{Code: "55P03"}, "models: unable to update books row"):                       true,
+		errors.Wrap(context.DeadlineExceeded, "models: failed to execute a one query for books"):          true,
+		errors.Wrap(&CanceledError{Err: &pq.Error{Code: "57014"}}, "models: unable to update books row"):  false,
+		errors.Wrap(context.Canceled, "models: failed to execute a one query for books"):                  false,
+		errors.Wrap(&pq.Error{Code: "23505"}, "models: unable to insert into books"):                      false,
+		sql.ErrNoRows: false,
+	}
+
+	for err, want := range tests {
+		if got := IsTimeout(err); got != want {
+			t.Errorf("%v: want %t, got %t", err, want, got)
+		}
+	}
+}
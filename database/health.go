@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/friendsofgo/errors"
+)
+
+// PoolStats は sql.DBStats のうち監視に使う値
+type PoolStats struct {
+	MaxOpenConnections int           `json:"max_open_connections"`
+	OpenConnections    int           `json:"open_connections"`
+	InUse              int           `json:"in_use"`
+	Idle               int           `json:"idle"`
+	WaitCount          int64         `json:"wait_count"`
+	WaitDuration       time.Duration `json:"wait_duration"`
+	MaxIdleClosed      int64         `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64         `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64         `json:"max_lifetime_closed"`
+}
+
+// Stats は接続プールの統計を返す
+func Stats(db *sql.DB) PoolStats {
+	s := db.Stats()
+	return PoolStats{
+		MaxOpenConnections: s.MaxOpenConnections,
+		OpenConnections:    s.OpenConnections,
+		InUse:              s.InUse,
+		Idle:               s.Idle,
+		WaitCount:          s.WaitCount,
+		WaitDuration:       s.WaitDuration,
+		MaxIdleClosed:      s.MaxIdleClosed,
+		MaxIdleTimeClosed:  s.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  s.MaxLifetimeClosed,
+	}
+}
+
+// Check は timeout 以内に Ping と SELECT 1 が成功するかを調べる
+func Check(ctx context.Context, db *sql.DB, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		return errors.Wrap(err, "database: ping failed")
+	}
+
+	var one int
+	if err := db.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+		return errors.Wrap(err, "database: SELECT 1 failed")
+	}
+	if one != 1 {
+		return errors.Errorf("database: SELECT 1 returned %d", one)
+	}
+
+	return nil
+}
+
+// Health はヘルスチェックの結果
+type Health struct {
+	Status  string        `json:"status"`
+	Error   string        `json:"error,omitempty"`
+	Latency time.Duration `json:"latency"`
+	Pool    PoolStats     `json:"pool"`
+}
+
+// CheckHealth は Check の結果と接続プールの統計をまとめて返す
+func CheckHealth(ctx context.Context, db *sql.DB, timeout time.Duration) Health {
+	start := time.Now()
+	err := Check(ctx, db, timeout)
+
+	h := Health{Status: "ok", Latency: time.Since(start), Pool: Stats(db)}
+	if err != nil {
+		h.Status = "unavailable"
+		h.Error = err.Error()
+	}
+
+	return h
+}
+
+// HealthHandler は CheckHealth の結果を JSON で返す。失敗時は 503 を返す。
+func HealthHandler(db *sql.DB, timeout time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := CheckHealth(r.Context(), db, timeout)
+
+		w.Header().Set("Content-Type", "application/json")
+		if len(h.Error) != 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(h)
+	})
+}
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"time"
+
+	"github.com/friendsofgo/errors"
+)
+
+// *sql.Row は database/sql の外では作れないので、QueryRowContext は QueryContext で実行した結果を
+// このパッケージだけで使うドライバ（rowDriver）に渡し、そこから *sql.Row を作る。
+// こうすると実行や読み出しのエラーも wrapErr を通して Scan から返せる。
+
+var rowDB = sql.OpenDB(rowConnector{})
+
+type rowKey struct{}
+
+// row は QueryContext で実行した結果
+type row struct {
+	ctx   context.Context
+	rows  *sql.Rows
+	op    Op
+	d     time.Duration
+	query string
+}
+
+// rowResult は rowDriver に渡す結果。err があれば Scan はそれを返す。
+type rowResult struct {
+	row *row
+	err error
+}
+
+// newRow は r の結果か err を返す *sql.Row を作る
+func newRow(ctx context.Context, r *row, err error) *sql.Row {
+	// rowDB の文は ctx のキャンセルで中断させない。r の読み出しは元の ctx のデッドラインで止まる。
+	ctx = context.WithValue(context.WithoutCancel(ctx), rowKey{}, &rowResult{row: r, err: err})
+	return rowDB.QueryRowContext(ctx, "")
+}
+
+type rowConnector struct{}
+
+func (rowConnector) Connect(context.Context) (driver.Conn, error) { return rowConn{}, nil }
+func (rowConnector) Driver() driver.Driver                        { return nil }
+
+type rowConn struct{}
+
+func (rowConn) Prepare(query string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (rowConn) Close() error                              { return nil }
+
+func (rowConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("database: row connection cannot begin transactions")
+}
+
+func (rowConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	res, ok := ctx.Value(rowKey{}).(*rowResult)
+	if !ok {
+		return nil, errors.New("database: no row result in context")
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	cols, err := res.row.rows.Columns()
+	if err != nil {
+		_ = res.row.rows.Close()
+		return nil, res.row.wrapErr(err)
+	}
+
+	return &rowRows{row: res.row, cols: cols}, nil
+}
+
+// rowRows は *sql.Rows の値をそのまま返す driver.Rows
+type rowRows struct {
+	row  *row
+	cols []string
+}
+
+func (r *rowRows) Columns() []string {
+	return r.cols
+}
+
+func (r *rowRows) Close() error {
+	return r.row.wrapErr(r.row.rows.Close())
+}
+
+func (r *rowRows) Next(dest []driver.Value) error {
+	if !r.row.rows.Next() {
+		if err := r.row.rows.Err(); err != nil {
+			return r.row.wrapErr(err)
+		}
+		return io.EOF
+	}
+
+	vals := make([]interface{}, len(dest))
+	ptrs := make([]interface{}, len(dest))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+	if err := r.row.rows.Scan(ptrs...); err != nil {
+		return r.row.wrapErr(err)
+	}
+	for i, v := range vals {
+		dest[i] = v
+	}
+
+	return nil
+}
+
+func (r *row) wrapErr(err error) error {
+	return wrapErr(r.ctx, err, r.op, r.d, r.query)
+}
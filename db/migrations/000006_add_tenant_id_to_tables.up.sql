@@ -0,0 +1,47 @@
+-- テナント ID はセッション変数 app.tenant_id から補完する。
+-- 未設定のまま書き込まれた行（既存行を含む）はテナント 0 に属する。
+ALTER TABLE books ADD COLUMN tenant_id INTEGER NOT NULL
+    DEFAULT COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), '0')::integer;
+ALTER TABLE users ADD COLUMN tenant_id INTEGER NOT NULL
+    DEFAULT COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), '0')::integer;
+ALTER TABLE movies ADD COLUMN tenant_id INTEGER NOT NULL
+    DEFAULT COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), '0')::integer;
+
+CREATE INDEX books_tenant_id_idx ON books (tenant_id);
+CREATE INDEX users_tenant_id_idx ON users (tenant_id);
+CREATE INDEX movies_tenant_id_idx ON movies (tenant_id);
+
+-- メールアドレスはテナントごとに一意
+ALTER TABLE users DROP CONSTRAINT users_email_key;
+ALTER TABLE users ADD CONSTRAINT users_tenant_id_email_key UNIQUE (tenant_id, email);
+
+-- app.tenant_id が未設定の場合は NULL との比較になり、どの行も見えない
+ALTER TABLE books ENABLE ROW LEVEL SECURITY;
+ALTER TABLE books FORCE ROW LEVEL SECURITY;
+CREATE POLICY books_tenant_isolation ON books
+    USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::integer)
+    WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::integer);
+
+ALTER TABLE users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE users FORCE ROW LEVEL SECURITY;
+CREATE POLICY users_tenant_isolation ON users
+    USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::integer)
+    WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::integer);
+
+ALTER TABLE movies ENABLE ROW LEVEL SECURITY;
+ALTER TABLE movies FORCE ROW LEVEL SECURITY;
+CREATE POLICY movies_tenant_isolation ON movies
+    USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::integer)
+    WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::integer);
+
+-- スーパーユーザーは RLS を素通りするため、アプリケーションはこのロールに切り替えて接続する
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'app_tenant') THEN
+        CREATE ROLE app_tenant NOLOGIN;
+    END IF;
+END
+$$;
+
+GRANT SELECT, INSERT, UPDATE, DELETE ON books, users, movies, user_favorite_movies, outbox_events TO app_tenant;
+GRANT USAGE ON ALL SEQUENCES IN SCHEMA public TO app_tenant;
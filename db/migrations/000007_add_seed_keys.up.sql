@@ -0,0 +1,8 @@
+-- seed が Upsert で使うキー（テナントごとに一意）。seed で入れた行にだけ自然キーを入れる。
+-- タイトルや著者そのものには一意制約を付けない。seed 以外の行は NULL のままなので、
+-- 既存の重複した行があっても適用でき、同じタイトルの版違いの本や同名の映画もこれまでどおり登録できる。
+ALTER TABLE books ADD COLUMN seed_key TEXT;
+ALTER TABLE movies ADD COLUMN seed_key TEXT;
+
+ALTER TABLE books ADD CONSTRAINT books_tenant_id_seed_key_key UNIQUE (tenant_id, seed_key);
+ALTER TABLE movies ADD CONSTRAINT movies_tenant_id_seed_key_key UNIQUE (tenant_id, seed_key);
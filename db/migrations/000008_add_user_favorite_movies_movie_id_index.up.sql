@@ -0,0 +1,3 @@
+-- 映画ごとのお気に入り数（MovieCountWhere.FavoritedBy など）を movie_id から引くための索引
+-- user_id からは主キー (user_id, movie_id) の索引で引ける
+CREATE INDEX user_favorite_movies_movie_id_idx ON user_favorite_movies (movie_id);
@@ -0,0 +1,66 @@
+-- 著者の表記ゆれ（前後・連続する空白、大文字小文字）を同一視するための正規化
+CREATE FUNCTION normalize_author_name(name TEXT) RETURNS TEXT
+    LANGUAGE SQL IMMUTABLE STRICT
+    AS $$ SELECT lower(regexp_replace(btrim(name), '\s+', ' ', 'g')) $$;
+
+CREATE TABLE authors (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id INTEGER NOT NULL
+        DEFAULT COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), '0')::integer
+);
+
+CREATE INDEX authors_tenant_id_idx ON authors (tenant_id);
+CREATE UNIQUE INDEX authors_tenant_id_normalized_name_key ON authors (tenant_id, normalize_author_name(name));
+
+-- 移行期間中は books.author を残し、author_id は未設定（NULL）も許す
+ALTER TABLE books ADD COLUMN author_id INTEGER REFERENCES authors (id);
+CREATE INDEX books_author_id_idx ON books (author_id);
+
+-- 既存の books.author から著者を作る。表記ゆれは正規化した名前でまとめ、
+-- 代表の表記には最も多く使われているもの（同数なら辞書順で最初のもの）を使う。
+INSERT INTO authors (tenant_id, name)
+SELECT DISTINCT ON (tenant_id, normalize_author_name(author))
+    tenant_id, regexp_replace(btrim(author), '\s+', ' ', 'g')
+FROM (
+    SELECT tenant_id, author, count(*) AS n
+    FROM books
+    GROUP BY tenant_id, author
+) spellings
+ORDER BY tenant_id, normalize_author_name(author), n DESC, author;
+
+UPDATE books
+SET author_id = authors.id
+FROM authors
+WHERE authors.tenant_id = books.tenant_id
+    AND normalize_author_name(authors.name) = normalize_author_name(books.author);
+
+ALTER TABLE authors ENABLE ROW LEVEL SECURITY;
+ALTER TABLE authors FORCE ROW LEVEL SECURITY;
+CREATE POLICY authors_tenant_isolation ON authors
+    USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::integer)
+    WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::integer);
+
+-- 移行期間中の互換ビュー。books と同じ列を持ち、author は authors の名前（未設定なら books.author）になる。
+-- searchBooks のような author での検索は FROM をこのビューに替えるだけで動く。
+-- PostgreSQL 14 のビューは所有者の権限で読むので RLS が効かない。同じ条件をビューに書き、
+-- RLS を素通りするロール（スーパーユーザーなど）にだけ全テナントを見せる。
+CREATE VIEW books_with_author AS
+SELECT
+    books.id,
+    books.title,
+    COALESCE(authors.name, books.author) AS author,
+    books.published_year,
+    books.created_at,
+    books.tenant_id,
+    books.seed_key,
+    books.author_id
+FROM books
+LEFT JOIN authors ON authors.id = books.author_id
+WHERE books.tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::integer
+    OR (SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user);
+
+GRANT SELECT, INSERT, UPDATE, DELETE ON authors TO app_tenant;
+GRANT SELECT ON books_with_author TO app_tenant;
+GRANT USAGE ON SEQUENCE authors_id_seq TO app_tenant;
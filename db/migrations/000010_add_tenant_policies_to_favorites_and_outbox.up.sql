@@ -0,0 +1,33 @@
+-- 000006 で app_tenant に公開した user_favorite_movies と outbox_events にも RLS を掛ける。
+
+-- お気に入りは tenant_id を持たず、ユーザーと映画のテナントに従う。
+-- ポリシーのサブクエリにも users と movies の RLS が効くので、見えるユーザーの行だけが見え、
+-- 見えるユーザーと映画の組み合わせだけを書き込める。
+ALTER TABLE user_favorite_movies ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_favorite_movies FORCE ROW LEVEL SECURITY;
+CREATE POLICY user_favorite_movies_tenant_isolation ON user_favorite_movies
+    USING (EXISTS (SELECT 1 FROM users WHERE users.id = user_favorite_movies.user_id))
+    WITH CHECK (
+        EXISTS (SELECT 1 FROM users WHERE users.id = user_favorite_movies.user_id)
+        AND EXISTS (SELECT 1 FROM movies WHERE movies.id = user_favorite_movies.movie_id)
+    );
+
+-- outbox のペイロードには行がそのまま入るので、書き込んだテナントにしか見せない。
+-- 全テナントのイベントを配信するリレーは RLS を素通りするロールで接続する。
+ALTER TABLE outbox_events ADD COLUMN tenant_id INTEGER NOT NULL
+    DEFAULT COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), '0')::integer;
+
+-- 既存の本のイベントは本のテナントに移す。それ以外はテナント 0 に属する。
+UPDATE outbox_events
+SET tenant_id = books.tenant_id
+FROM books
+WHERE outbox_events.aggregate_type = 'books'
+    AND outbox_events.aggregate_id = books.id::text;
+
+CREATE INDEX outbox_events_tenant_id_idx ON outbox_events (tenant_id);
+
+ALTER TABLE outbox_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE outbox_events FORCE ROW LEVEL SECURITY;
+CREATE POLICY outbox_events_tenant_isolation ON outbox_events
+    USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::integer)
+    WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::integer);
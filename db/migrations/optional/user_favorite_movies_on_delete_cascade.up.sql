@@ -0,0 +1,13 @@
+-- 任意のマイグレーション: ユーザーや映画を削除したときにお気に入りをデータベース側で削除する。
+-- 採用する場合は次の番号を付けて db/migrations に移す。
+-- 採用後もモデルの DeleteWith は使える（DeleteCascade は子の削除フックを実行するために先に子を消し、
+-- DeleteRestrict は削除前に件数を調べて RestrictError を返す）。生成された Delete もエラーにならなくなる。
+ALTER TABLE user_favorite_movies
+    DROP CONSTRAINT user_favorite_movies_user_id_fkey,
+    ADD CONSTRAINT user_favorite_movies_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE user_favorite_movies
+    DROP CONSTRAINT user_favorite_movies_movie_id_fkey,
+    ADD CONSTRAINT user_favorite_movies_movie_id_fkey
+        FOREIGN KEY (movie_id) REFERENCES movies (id) ON DELETE CASCADE;
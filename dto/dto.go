@@ -0,0 +1,175 @@
+// Package dto はモデルを API で返す形（DTO）にするためのパッケージ。
+// 内部用のカラム（tenant_id など）や公開しないカラムを除き、読み込み済みのリレーションを埋め込む。
+// JSON にするときは Marshal で返すフィールドの選択（fields=）とキーの書式（snake_case / camelCase）を指定できる。
+package dto
+
+import (
+	"time"
+
+	"github.com/volatiletech/null/v8"
+
+	"sqlboiler-project/models"
+)
+
+// DTO のフィールドの json タグは snake_case で書く。Marshal はこの名前を基準にフィールドを選び、キーの書式を変える。
+// NULL になりうるカラムはポインタにして null を返し、omitempty は読み込んでいないリレーションにだけ付ける。
+
+// BookPublic は API で返す Book。created_at と tenant_id は返さない。
+type BookPublic struct {
+	ID            int           `json:"id"`
+	Title         string        `json:"title"`
+	AuthorName    string        `json:"author_name"`
+	PublishedYear *int          `json:"published_year"`
+	Author        *AuthorPublic `json:"author,omitempty"`
+}
+
+// AuthorPublic は API で返す Author
+type AuthorPublic struct {
+	ID    int          `json:"id"`
+	Name  string       `json:"name"`
+	Books []BookPublic `json:"books,omitempty"`
+}
+
+// UserPublic は API で返す User。email と tenant_id は返さない。
+type UserPublic struct {
+	ID             int                   `json:"id"`
+	Name           string                `json:"name"`
+	CreatedAt      *time.Time            `json:"created_at"`
+	FavoriteMovies []FavoriteMoviePublic `json:"favorite_movies,omitempty"`
+}
+
+// MoviePublic は API で返す Movie
+type MoviePublic struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	ReleaseYear *int   `json:"release_year"`
+}
+
+// FavoriteMoviePublic はお気に入りの映画と登録日時
+type FavoriteMoviePublic struct {
+	Movie       MoviePublic `json:"movie"`
+	FavoritedAt *time.Time  `json:"favorited_at"`
+}
+
+// 本と著者は LoadAuthor などで互いを R に持つので、埋め込むのは一段だけにする。
+// 本に埋め込んだ著者には books を、著者に埋め込んだ本には author を入れない。
+
+// NewBookPublic は b を BookPublic にする。R.Author を読み込んでいれば author に埋め込む。
+func NewBookPublic(b *models.Book) BookPublic {
+	p := newBookPublic(b)
+	if b.R != nil && b.R.Author != nil {
+		a := newAuthorPublic(b.R.Author)
+		p.Author = &a
+	}
+
+	return p
+}
+
+// newBookPublic はリレーションを埋め込まずに b を BookPublic にする
+func newBookPublic(b *models.Book) BookPublic {
+	return BookPublic{
+		ID:            b.ID,
+		Title:         b.Title,
+		AuthorName:    b.AuthorName,
+		PublishedYear: intPtr(b.PublishedYear),
+	}
+}
+
+// NewBookPublics は books を BookPublic のスライスにする。books が空でも nil ではなく空のスライスを返す。
+func NewBookPublics(books models.BookSlice) []BookPublic {
+	ps := make([]BookPublic, len(books))
+	for i, b := range books {
+		ps[i] = NewBookPublic(b)
+	}
+
+	return ps
+}
+
+// NewAuthorPublic は a を AuthorPublic にする。R.Books を読み込んでいれば books に埋め込む。
+func NewAuthorPublic(a *models.Author) AuthorPublic {
+	p := newAuthorPublic(a)
+	if a.R != nil && a.R.Books != nil {
+		p.Books = make([]BookPublic, len(a.R.Books))
+		for i, b := range a.R.Books {
+			p.Books[i] = newBookPublic(b)
+		}
+	}
+
+	return p
+}
+
+// newAuthorPublic はリレーションを埋め込まずに a を AuthorPublic にする
+func newAuthorPublic(a *models.Author) AuthorPublic {
+	return AuthorPublic{ID: a.ID, Name: a.Name}
+}
+
+// NewUserPublic は u を UserPublic にする。
+// R.UserFavoriteMovies とその R.Movie を読み込んでいれば favorite_movies に埋め込む。
+func NewUserPublic(u *models.User) UserPublic {
+	p := UserPublic{ID: u.ID, Name: u.Name, CreatedAt: timePtr(u.CreatedAt)}
+	if u.R != nil && u.R.UserFavoriteMovies != nil {
+		p.FavoriteMovies = make([]FavoriteMoviePublic, 0, len(u.R.UserFavoriteMovies))
+		for _, fav := range u.R.UserFavoriteMovies {
+			if fav.R == nil || fav.R.Movie == nil {
+				continue
+			}
+			p.FavoriteMovies = append(p.FavoriteMovies, FavoriteMoviePublic{
+				Movie:       NewMoviePublic(fav.R.Movie),
+				FavoritedAt: timePtr(fav.CreatedAt),
+			})
+		}
+	}
+
+	return p
+}
+
+// NewUserPublics は users を UserPublic のスライスにする
+func NewUserPublics(users models.UserSlice) []UserPublic {
+	ps := make([]UserPublic, len(users))
+	for i, u := range users {
+		ps[i] = NewUserPublic(u)
+	}
+
+	return ps
+}
+
+// NewMoviePublic は m を MoviePublic にする
+func NewMoviePublic(m *models.Movie) MoviePublic {
+	return MoviePublic{ID: m.ID, Title: m.Title, ReleaseYear: intPtr(m.ReleaseYear)}
+}
+
+// NewMoviePublics は movies を MoviePublic のスライスにする
+func NewMoviePublics(movies models.MovieSlice) []MoviePublic {
+	ps := make([]MoviePublic, len(movies))
+	for i, m := range movies {
+		ps[i] = NewMoviePublic(m)
+	}
+
+	return ps
+}
+
+// NewFavoriteMoviePublics は User.FavoriteMovies の結果を FavoriteMoviePublic のスライスにする
+func NewFavoriteMoviePublics(favs models.FavoriteMovieSlice) []FavoriteMoviePublic {
+	ps := make([]FavoriteMoviePublic, len(favs))
+	for i, f := range favs {
+		ps[i] = FavoriteMoviePublic{Movie: NewMoviePublic(&f.Movie), FavoritedAt: timePtr(f.FavoritedAt)}
+	}
+
+	return ps
+}
+
+func intPtr(v null.Int) *int {
+	if !v.Valid {
+		return nil
+	}
+	i := v.Int
+	return &i
+}
+
+func timePtr(v null.Time) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	t := v.Time
+	return &t
+}
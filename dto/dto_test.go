@@ -0,0 +1,177 @@
+package dto
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+
+	"sqlboiler-project/models"
+)
+
+func testBook() *models.Book {
+	b := &models.Book{
+		ID:            1,
+		Title:         "Solaris",
+		AuthorName:    "Stanisław Lem",
+		PublishedYear: null.IntFrom(1961),
+		CreatedAt:     null.TimeFrom(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		TenantID:      7,
+		AuthorID:      null.IntFrom(3),
+	}
+	b.R = b.R.NewStruct()
+	b.R.Author = &models.Author{ID: 3, Name: "Stanisław Lem", TenantID: 7}
+
+	return b
+}
+
+func testUser() *models.User {
+	u := &models.User{
+		ID:        2,
+		Name:      "Alice",
+		Email:     "alice@example.com",
+		CreatedAt: null.TimeFrom(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+		TenantID:  7,
+	}
+	fav := &models.UserFavoriteMovie{UserID: 2, MovieID: 5, CreatedAt: null.TimeFrom(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))}
+	fav.R = fav.R.NewStruct()
+	fav.R.Movie = &models.Movie{ID: 5, Title: "Stalker", TenantID: 7}
+	u.R = u.R.NewStruct()
+	u.R.UserFavoriteMovies = models.UserFavoriteMovieSlice{fav}
+
+	return u
+}
+
+func TestBookPublic(t *testing.T) {
+	t.Parallel()
+
+	got, err := Marshal(NewBookPublic(testBook()), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":1,"title":"Solaris","author_name":"Stanisław Lem","published_year":1961,"author":{"id":3,"name":"Stanisław Lem"}}`
+	if string(got) != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	// リレーションを読み込んでいなければ author を返さない
+	b := testBook()
+	b.R = nil
+	b.PublishedYear = null.Int{}
+	got, err = Marshal(NewBookPublic(b), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `{"id":1,"title":"Solaris","author_name":"Stanisław Lem","published_year":null}`
+	if string(got) != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestBookAuthorCycle(t *testing.T) {
+	t.Parallel()
+
+	// LoadAuthor と LoadBooks は本と著者の R に互いを入れる
+	b := testBook()
+	a := b.R.Author
+	a.R = a.R.NewStruct()
+	a.R.Books = models.BookSlice{b}
+
+	got, err := Marshal(NewBookPublic(b), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":1,"title":"Solaris","author_name":"Stanisław Lem","published_year":1961,"author":{"id":3,"name":"Stanisław Lem"}}`
+	if string(got) != want {
+		t.Errorf("book: got  %s\nwant %s", got, want)
+	}
+
+	got, err = Marshal(NewAuthorPublic(a), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `{"id":3,"name":"Stanisław Lem","books":[{"id":1,"title":"Solaris","author_name":"Stanisław Lem","published_year":1961}]}`
+	if string(got) != want {
+		t.Errorf("author: got  %s\nwant %s", got, want)
+	}
+}
+
+func TestUserPublic(t *testing.T) {
+	t.Parallel()
+
+	got, err := Marshal([]UserPublic{NewUserPublic(testUser())}, Options{Case: CamelCase})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"id":2,"name":"Alice","createdAt":"2024-01-02T00:00:00Z",` +
+		`"favoriteMovies":[{"movie":{"id":5,"title":"Stalker","releaseYear":null},"favoritedAt":"2024-02-01T00:00:00Z"}]}]`
+	if string(got) != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+	if strings.Contains(string(got), "alice@example.com") {
+		t.Error("email must not be serialized")
+	}
+}
+
+func TestMarshalFields(t *testing.T) {
+	t.Parallel()
+
+	books := []BookPublic{NewBookPublic(testBook())}
+	got, err := Marshal(books, Options{Fields: ParseFields("title, author.name,publishedYear"), Case: CamelCase})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"title":"Solaris","publishedYear":1961,"author":{"name":"Stanisław Lem"}}]`
+	if string(got) != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	for _, fields := range []string{"created_at", "author.email", "title.length"} {
+		if _, err := Marshal(books, Options{Fields: ParseFields(fields)}); err == nil {
+			t.Errorf("fields=%s: want an error", fields)
+		}
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want Fields
+	}{
+		{"", nil},
+		{" , ", nil},
+		{"id,title", Fields{"id": nil, "title": nil}},
+		{"author.name,author.id", Fields{"author": Fields{"name": nil, "id": nil}}},
+		{"author.name,author", Fields{"author": nil}},
+		{"author,author.name", Fields{"author": nil}},
+		{"favoriteMovies.movie.releaseYear", Fields{"favorite_movies": Fields{"movie": Fields{"release_year": nil}}}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseFields(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseFields(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCase(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]string{"id": "id", "author_id": "authorId", "favorite_movies": "favoriteMovies"} {
+		if got := CamelCase.key(in); got != want {
+			t.Errorf("CamelCase.key(%q) = %q, want %q", in, got, want)
+		}
+	}
+	for in, want := range map[string]string{"AuthorID": "author_id", "publishedYear": "published_year", "id": "id"} {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if _, err := ParseCase("kebab"); err == nil {
+		t.Error("want an error for an unknown case")
+	}
+}
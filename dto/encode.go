@@ -0,0 +1,309 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/friendsofgo/errors"
+)
+
+// Case は JSON のキーの書式
+type Case int
+
+// JSON のキーの書式
+const (
+	// SnakeCase は json タグのまま（published_year）
+	SnakeCase Case = iota
+	// CamelCase は先頭を小文字にした camelCase（publishedYear）。author_id は authorId になる。
+	CamelCase
+)
+
+// ParseCase は case= パラメータを Case にする。空なら SnakeCase。
+func ParseCase(s string) (Case, error) {
+	switch strings.ToLower(s) {
+	case "", "snake", "snake_case":
+		return SnakeCase, nil
+	case "camel", "camelcase":
+		return CamelCase, nil
+	}
+
+	return SnakeCase, errors.Errorf("dto: unknown case %q (want snake or camel)", s)
+}
+
+// Fields は返すフィールド。キーは snake_case のフィールド名で、値は埋め込んだオブジェクトのうち返すフィールド。
+// 値が nil のフィールドはすべてのサブフィールドを返す。Fields 自体が nil ならすべてのフィールドを返す。
+type Fields map[string]Fields
+
+// ParseFields は fields= パラメータ（"id,title,author.name"）を Fields にする。
+// フィールド名は snake_case でも camelCase でもよい。空文字なら nil（すべて）を返す。
+func ParseFields(s string) Fields {
+	var fields Fields
+	for _, path := range strings.Split(s, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		if fields == nil {
+			fields = Fields{}
+		}
+
+		f := fields
+		parts := strings.Split(path, ".")
+		for i, part := range parts {
+			name := snakeCase(part)
+			sub, ok := f[name]
+			if i == len(parts)-1 {
+				// "author" と "author.name" の両方があれば author はすべて返す
+				if !ok || len(sub) != 0 {
+					f[name] = nil
+				}
+				break
+			}
+			if ok && sub == nil {
+				break
+			}
+			if sub == nil {
+				sub = Fields{}
+				f[name] = sub
+			}
+			f = sub
+		}
+	}
+
+	return fields
+}
+
+// Options は Marshal の指定
+type Options struct {
+	Case   Case
+	Fields Fields
+}
+
+// Marshal は DTO（そのスライスやポインタでもよい）を opts に従って JSON にする。
+// キーは DTO のフィールドの順に並ぶ。Fields に DTO に無いフィールドがあればエラーを返す。
+func Marshal(v interface{}, opts Options) ([]byte, error) {
+	projected, err := Project(v, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(projected)
+}
+
+// Project は DTO を opts に従ったフィールドだけを持つ値にする。
+// 結果はそのまま json.Marshal でき、Marshal と同じ JSON になる。
+func Project(v interface{}, opts Options) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+	if err := checkFields(elemType(rv.Type()), opts.Fields, ""); err != nil {
+		return nil, err
+	}
+
+	return project(rv, opts.Fields, opts.Case), nil
+}
+
+// Object はキーの順序を保つ JSON オブジェクト
+type Object []Member
+
+// Member は Object の 1 つのキーと値
+type Member struct {
+	Key   string
+	Value interface{}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(m.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// dtoField は DTO の 1 つのフィールド
+type dtoField struct {
+	index     int
+	name      string
+	omitEmpty bool
+}
+
+// dtoFields は t の json タグの付いたフィールドを返す
+func dtoFields(t reflect.Type) []dtoField {
+	var fields []dtoField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = snakeCase(sf.Name)
+		}
+		fields = append(fields, dtoField{index: i, name: name, omitEmpty: opts == "omitempty"})
+	}
+
+	return fields
+}
+
+// isObject は t を JSON オブジェクトとして展開するかを返す。json.Marshaler を持つ型（time.Time など）はそのまま返す。
+func isObject(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	marshaler := reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+	return !t.Implements(marshaler) && !reflect.PointerTo(t).Implements(marshaler)
+}
+
+// elemType はポインタやスライスを外した型を返す
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// checkFields は fields が t に無いフィールドを指していないか調べる
+func checkFields(t reflect.Type, fields Fields, prefix string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	if !isObject(t) {
+		return errors.Errorf("dto: field %q has no subfields", strings.TrimSuffix(prefix, "."))
+	}
+
+	known := map[string]reflect.Type{}
+	for _, f := range dtoFields(t) {
+		known[f.name] = elemType(t.Field(f.index).Type)
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		ft, ok := known[name]
+		if !ok {
+			return errors.Errorf("dto: unknown field %q", prefix+name)
+		}
+		if err := checkFields(ft, fields[name], prefix+name+"."); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func project(v reflect.Value, fields Fields, c Case) interface{} {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return project(v.Elem(), fields, c)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if !isObject(elemType(v.Type())) {
+			return v.Interface()
+		}
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = project(v.Index(i), fields, c)
+		}
+		return items
+	case reflect.Struct:
+		if !isObject(v.Type()) {
+			return v.Interface()
+		}
+	default:
+		return v.Interface()
+	}
+
+	obj := Object{}
+	for _, f := range dtoFields(v.Type()) {
+		sub, selected := fields[f.name]
+		if fields != nil && !selected {
+			continue
+		}
+
+		fv := v.Field(f.index)
+		if f.omitEmpty && isEmpty(fv) {
+			continue
+		}
+		obj = append(obj, Member{Key: c.key(f.name), Value: project(fv, sub, c)})
+	}
+
+	return obj
+}
+
+// isEmpty は omitempty で省く値かを返す。読み込んでいないリレーション（nil）だけを省く。
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return v.IsNil()
+	}
+
+	return false
+}
+
+// key は snake_case の name を c の書式にする
+func (c Case) key(name string) string {
+	if c != CamelCase {
+		return name
+	}
+
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+
+	return strings.Join(parts, "")
+}
+
+// snakeCase は camelCase（publishedYear）や Go のフィールド名（PublishedYear）を snake_case にする
+func snakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// 連続した大文字（ID など）は 1 語として扱う
+			if i > 0 && (!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
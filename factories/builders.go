@@ -0,0 +1,254 @@
+package factories
+
+import (
+	"context"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"sqlboiler-project/models"
+)
+
+// 値を入れずに DB のデフォルトに任せるカラム
+var randomizeBlacklist = []string{"id", "created_at", "tenant_id", "author_id"}
+
+func randomStruct(f *Factory, o interface{}, colTypes map[string]string) {
+	// 型表はこのパッケージで固定しているので失敗しない
+	if err := randomize.Struct(f.seed, o, colTypes, false, randomizeBlacklist...); err != nil {
+		panic(err)
+	}
+}
+
+// BookBuilder は Book を組み立てる
+type BookBuilder struct {
+	book    *models.Book
+	created bool
+}
+
+// Book は妥当な値を持つ Book の builder を返す
+func (f *Factory) Book() *BookBuilder {
+	o := &models.Book{}
+	randomStruct(f, o, bookDBTypes)
+
+	o.Title = f.title()
+	o.AuthorName = f.personName()
+	o.PublishedYear = null.IntFrom(f.year(minBookYear))
+
+	return &BookBuilder{book: o}
+}
+
+// Title はタイトルを設定する
+func (b *BookBuilder) Title(title string) *BookBuilder {
+	b.book.Title = title
+	return b
+}
+
+// Author は著者を設定する
+func (b *BookBuilder) Author(author string) *BookBuilder {
+	b.book.AuthorName = author
+	return b
+}
+
+// PublishedYear は出版年を設定する
+func (b *BookBuilder) PublishedYear(year int) *BookBuilder {
+	b.book.PublishedYear = null.IntFrom(year)
+	return b
+}
+
+// Build は保存せずに Book を返す
+func (b *BookBuilder) Build() *models.Book {
+	return b.book
+}
+
+// Create は Book を保存して返す。保存済みなら何もしない。
+func (b *BookBuilder) Create(ctx context.Context, exec boil.ContextExecutor) (*models.Book, error) {
+	if b.created {
+		return b.book, nil
+	}
+	if err := b.book.Insert(ctx, exec, boil.Infer()); err != nil {
+		return nil, errors.Wrap(err, "factories: unable to create book")
+	}
+	b.created = true
+
+	return b.book, nil
+}
+
+// MovieBuilder は Movie を組み立てる
+type MovieBuilder struct {
+	movie   *models.Movie
+	created bool
+}
+
+// Movie は妥当な値を持つ Movie の builder を返す
+func (f *Factory) Movie() *MovieBuilder {
+	o := &models.Movie{}
+	randomStruct(f, o, movieDBTypes)
+
+	o.Title = f.title()
+	o.ReleaseYear = null.IntFrom(f.year(minMovieYear))
+
+	return &MovieBuilder{movie: o}
+}
+
+// Title はタイトルを設定する
+func (b *MovieBuilder) Title(title string) *MovieBuilder {
+	b.movie.Title = title
+	return b
+}
+
+// ReleaseYear は公開年を設定する
+func (b *MovieBuilder) ReleaseYear(year int) *MovieBuilder {
+	b.movie.ReleaseYear = null.IntFrom(year)
+	return b
+}
+
+// Build は保存せずに Movie を返す
+func (b *MovieBuilder) Build() *models.Movie {
+	return b.movie
+}
+
+// Create は Movie を保存して返す。保存済みなら何もしない。
+func (b *MovieBuilder) Create(ctx context.Context, exec boil.ContextExecutor) (*models.Movie, error) {
+	if b.created {
+		return b.movie, nil
+	}
+	if err := b.movie.Insert(ctx, exec, boil.Infer()); err != nil {
+		return nil, errors.Wrap(err, "factories: unable to create movie")
+	}
+	b.created = true
+
+	return b.movie, nil
+}
+
+// UserBuilder は User と、そのお気に入りの映画を組み立てる
+type UserBuilder struct {
+	user      *models.User
+	favorites []*MovieBuilder
+	created   bool
+}
+
+// User は妥当な値を持つ User の builder を返す。メールアドレスは Factory 内で一意になる。
+func (f *Factory) User() *UserBuilder {
+	o := &models.User{}
+	randomStruct(f, o, userDBTypes)
+
+	o.Name = f.personName()
+	o.Email = f.email()
+
+	return &UserBuilder{user: o}
+}
+
+// Name は名前を設定する
+func (b *UserBuilder) Name(name string) *UserBuilder {
+	b.user.Name = name
+	return b
+}
+
+// Email はメールアドレスを設定する
+func (b *UserBuilder) Email(email string) *UserBuilder {
+	b.user.Email = email
+	return b
+}
+
+// WithFavorites はお気に入りの映画を追加する。同じ MovieBuilder を複数のユーザーで共有してよい。
+func (b *UserBuilder) WithFavorites(movies ...*MovieBuilder) *UserBuilder {
+	b.favorites = append(b.favorites, movies...)
+	return b
+}
+
+// Build は保存せずに User を返す。お気に入りは含まない。
+func (b *UserBuilder) Build() *models.User {
+	return b.user
+}
+
+// Create は User を保存し、続けてお気に入りの映画と user_favorite_movies の行を保存する
+func (b *UserBuilder) Create(ctx context.Context, exec boil.ContextExecutor) (*models.User, error) {
+	if err := b.insert(ctx, exec); err != nil {
+		return nil, err
+	}
+
+	favorites := b.favorites
+	b.favorites = nil
+	for _, m := range favorites {
+		fav := &UserFavoriteMovieBuilder{user: b, movie: m, favorite: &models.UserFavoriteMovie{}}
+		if _, err := fav.Create(ctx, exec); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.user, nil
+}
+
+// insert は User の行だけを保存する
+func (b *UserBuilder) insert(ctx context.Context, exec boil.ContextExecutor) error {
+	if b.created {
+		return nil
+	}
+	if err := b.user.Insert(ctx, exec, boil.Infer()); err != nil {
+		return errors.Wrap(err, "factories: unable to create user")
+	}
+	b.created = true
+
+	return nil
+}
+
+// UserFavoriteMovieBuilder は UserFavoriteMovie を組み立てる。
+// ユーザーと映画が未保存なら先に保存する。
+type UserFavoriteMovieBuilder struct {
+	user     *UserBuilder
+	movie    *MovieBuilder
+	favorite *models.UserFavoriteMovie
+	created  bool
+}
+
+// UserFavoriteMovie は新しいユーザーと映画を結ぶ UserFavoriteMovie の builder を返す
+func (f *Factory) UserFavoriteMovie() *UserFavoriteMovieBuilder {
+	return &UserFavoriteMovieBuilder{
+		user:     f.User(),
+		movie:    f.Movie(),
+		favorite: &models.UserFavoriteMovie{},
+	}
+}
+
+// User はお気に入りに登録するユーザーを設定する
+func (b *UserFavoriteMovieBuilder) User(user *UserBuilder) *UserFavoriteMovieBuilder {
+	b.user = user
+	return b
+}
+
+// Movie はお気に入りに登録する映画を設定する
+func (b *UserFavoriteMovieBuilder) Movie(movie *MovieBuilder) *UserFavoriteMovieBuilder {
+	b.movie = movie
+	return b
+}
+
+// Build は保存せずに UserFavoriteMovie を返す。ユーザーと映画が未保存ならキーは 0 のまま。
+func (b *UserFavoriteMovieBuilder) Build() *models.UserFavoriteMovie {
+	b.favorite.UserID = b.user.user.ID
+	b.favorite.MovieID = b.movie.movie.ID
+	return b.favorite
+}
+
+// Create はユーザー、映画、UserFavoriteMovie の順に保存する
+func (b *UserFavoriteMovieBuilder) Create(ctx context.Context, exec boil.ContextExecutor) (*models.UserFavoriteMovie, error) {
+	if b.created {
+		return b.favorite, nil
+	}
+
+	if err := b.user.insert(ctx, exec); err != nil {
+		return nil, err
+	}
+	if _, err := b.movie.Create(ctx, exec); err != nil {
+		return nil, err
+	}
+
+	o := b.Build()
+	if err := o.Insert(ctx, exec, boil.Infer()); err != nil {
+		return nil, errors.Wrap(err, "factories: unable to create user favorite movie")
+	}
+	b.created = true
+
+	return o, nil
+}
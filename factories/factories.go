@@ -0,0 +1,104 @@
+// Package factories はテストや開発用に、妥当な値を持つモデルを組み立てて保存する。
+//
+//	f := factories.New()
+//	user, err := f.User().WithFavorites(f.Movie(), f.Movie(), f.Movie()).Create(ctx, exec)
+//
+// 値は randomize で埋めたうえで、メールアドレスの一意性や年の範囲など
+// スキーマだけでは表せない条件を満たすように上書きする。
+package factories
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/volatiletech/randomize"
+)
+
+// 生成する年の範囲
+const (
+	minBookYear  = 1900
+	minMovieYear = 1920
+	maxYear      = 2024
+)
+
+// randomize に渡すカラムの DB 型（生成テストの xxxDBTypes と同じもの）
+var (
+	bookDBTypes  = map[string]string{`ID`: `integer`, `Title`: `character varying`, `Author`: `character varying`, `PublishedYear`: `integer`, `CreatedAt`: `timestamp without time zone`, `TenantID`: `integer`, `AuthorID`: `integer`}
+	userDBTypes  = map[string]string{`ID`: `integer`, `Name`: `character varying`, `Email`: `character varying`, `CreatedAt`: `timestamp without time zone`, `TenantID`: `integer`}
+	movieDBTypes = map[string]string{`ID`: `integer`, `Title`: `character varying`, `ReleaseYear`: `integer`, `CreatedAt`: `timestamp without time zone`, `TenantID`: `integer`}
+)
+
+// 名前やタイトルの組み立てに使う語
+var (
+	firstNames = []string{"Aiko", "Haruto", "Yui", "Sota", "Mei", "Ren", "Emma", "Liam", "Olivia", "Noah", "Sofia", "Lucas"}
+	lastNames  = []string{"Sato", "Suzuki", "Takahashi", "Tanaka", "Ito", "Watanabe", "Smith", "Johnson", "Garcia", "Müller", "Rossi", "Martin"}
+	adjectives = []string{"Silent", "Hidden", "Last", "Golden", "Broken", "Distant", "Midnight", "Crimson", "Forgotten", "Endless"}
+	nouns      = []string{"River", "Garden", "Empire", "Voyage", "Letter", "Mountain", "City", "Harbor", "Promise", "Machine"}
+)
+
+// Factory はモデルの builder を作る。
+// NewWithSeed で作った Factory は連番（メールアドレスなどの一意な値）と乱数を自分で持つので、
+// 同じシードからは同じ順序で同じ値が作られる。
+type Factory struct {
+	seed *randomize.Seed
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// sharedSeed は New で作った Factory が共有する連番。生成テストのパッケージ変数 seed と同じ役割。
+var sharedSeed = randomize.NewSeed()
+
+// New は実行ごとに異なる値を作る Factory を返す。
+// 連番はプロセス内で共有するので、New で作った Factory 同士でメールアドレスは重複しない。
+func New() *Factory {
+	return &Factory{
+		seed: sharedSeed,
+		rnd:  rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// NewWithSeed は seed から決定的に値を作る Factory を返す。
+// 同じシードの Factory を同じ DB で使うとメールアドレスが重複するので注意。
+func NewWithSeed(seed int64) *Factory {
+	s := randomize.Seed(seed)
+	return &Factory{
+		seed: &s,
+		rnd:  rand.New(rand.NewSource(seed)),
+	}
+}
+
+// next は Factory 内で一意な連番を返す
+func (f *Factory) next() int64 {
+	return f.seed.NextInt()
+}
+
+func (f *Factory) intn(n int) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.rnd.Intn(n)
+}
+
+func (f *Factory) pick(words []string) string {
+	return words[f.intn(len(words))]
+}
+
+func (f *Factory) year(min int) int {
+	return min + f.intn(maxYear-min+1)
+}
+
+func (f *Factory) personName() string {
+	return f.pick(firstNames) + " " + f.pick(lastNames)
+}
+
+func (f *Factory) title() string {
+	return fmt.Sprintf("The %s %s", f.pick(adjectives), f.pick(nouns))
+}
+
+// email は Factory 内で重複しないメールアドレスを返す
+func (f *Factory) email() string {
+	return fmt.Sprintf("user%d@example.com", f.next())
+}
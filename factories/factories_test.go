@@ -0,0 +1,292 @@
+package factories
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder は INSERT を記録し、RETURNING に連番の id などを返すだけのドライバ
+type recorder struct {
+	mu     sync.Mutex
+	tables []string
+	lastID int64
+}
+
+var (
+	rgxInsertTable = regexp.MustCompile(`(?i)^INSERT INTO "(\w+)"`)
+	rgxReturning   = regexp.MustCompile(`(?i)RETURNING (.+)$`)
+)
+
+func (r *recorder) Connect(context.Context) (driver.Conn, error) { return &recorderConn{r: r}, nil }
+func (r *recorder) Driver() driver.Driver                        { return nil }
+
+func (r *recorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ret := r.tables
+	r.tables = nil
+	return ret
+}
+
+type recorderConn struct{ r *recorder }
+
+func (c *recorderConn) Prepare(query string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *recorderConn) Close() error                              { return nil }
+func (c *recorderConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+func (c *recorderConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.record(query)
+	return driver.RowsAffected(1), nil
+}
+
+func (c *recorderConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.record(query)
+
+	m := rgxReturning.FindStringSubmatch(strings.TrimSpace(query))
+	if m == nil {
+		return &rows{}, nil
+	}
+
+	var cols []string
+	var vals []driver.Value
+	for _, col := range strings.Split(m[1], ",") {
+		col = strings.Trim(col, `" `)
+		cols = append(cols, col)
+
+		switch col {
+		case "id":
+			c.r.mu.Lock()
+			c.r.lastID++
+			vals = append(vals, c.r.lastID)
+			c.r.mu.Unlock()
+		case "created_at":
+			vals = append(vals, time.Now())
+		default:
+			vals = append(vals, int64(0))
+		}
+	}
+
+	return &rows{cols: cols, vals: [][]driver.Value{vals}}, nil
+}
+
+func (c *recorderConn) record(query string) {
+	if m := rgxInsertTable.FindStringSubmatch(query); m != nil {
+		c.r.mu.Lock()
+		c.r.tables = append(c.r.tables, m[1])
+		c.r.mu.Unlock()
+	}
+}
+
+type rows struct {
+	cols []string
+	vals [][]driver.Value
+}
+
+func (r *rows) Columns() []string { return r.cols }
+func (r *rows) Close() error      { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.vals) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.vals[0])
+	r.vals = r.vals[1:]
+	return nil
+}
+
+func openRecorder(t *testing.T) (*sql.DB, *recorder) {
+	t.Helper()
+
+	r := &recorder{}
+	db := sql.OpenDB(r)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db, r
+}
+
+func TestBuildersProduceValidValues(t *testing.T) {
+	t.Parallel()
+
+	f := New()
+	emails := map[string]bool{}
+
+	for i := 0; i < 100; i++ {
+		u := f.User().Build()
+		if len(u.Name) == 0 {
+			t.Error("user name is empty")
+		}
+		if !strings.HasSuffix(u.Email, "@example.com") {
+			t.Errorf("unexpected email %q", u.Email)
+		}
+		if emails[u.Email] {
+			t.Errorf("duplicate email %q", u.Email)
+		}
+		emails[u.Email] = true
+
+		b := f.Book().Build()
+		if len(b.Title) == 0 || len(b.AuthorName) == 0 {
+			t.Errorf("book has empty title or author: %#v", b)
+		}
+		if y := b.PublishedYear.Int; !b.PublishedYear.Valid || y < minBookYear || y > maxYear {
+			t.Errorf("implausible published year %v", b.PublishedYear)
+		}
+
+		m := f.Movie().Build()
+		if y := m.ReleaseYear.Int; !m.ReleaseYear.Valid || y < minMovieYear || y > maxYear {
+			t.Errorf("implausible release year %v", m.ReleaseYear)
+		}
+		if m.ID != 0 || m.TenantID != 0 || m.CreatedAt.Valid {
+			t.Errorf("columns with defaults should be left to the database: %#v", m)
+		}
+	}
+
+	// New で作った別の Factory とも重複しない
+	if u := New().User().Build(); emails[u.Email] {
+		t.Errorf("duplicate email %q across factories", u.Email)
+	}
+}
+
+func TestNewWithSeedIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	a, b := NewWithSeed(42), NewWithSeed(42)
+	for i := 0; i < 10; i++ {
+		ua, ub := a.User().Build(), b.User().Build()
+		if ua.Name != ub.Name || ua.Email != ub.Email {
+			t.Errorf("users differ: %#v %#v", ua, ub)
+		}
+		ba, bb := a.Book().Build(), b.Book().Build()
+		if !reflect.DeepEqual(ba, bb) {
+			t.Errorf("books differ: %#v %#v", ba, bb)
+		}
+	}
+}
+
+func TestUserWithFavoritesCreatesInDependencyOrder(t *testing.T) {
+	t.Parallel()
+
+	db, r := openRecorder(t)
+	ctx := context.Background()
+	f := New()
+
+	shared := f.Movie()
+	alice, err := f.User().WithFavorites(shared, f.Movie(), f.Movie()).Create(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := f.User().WithFavorites(shared).Create(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"users",
+		"movies", "user_favorite_movies",
+		"movies", "user_favorite_movies",
+		"movies", "user_favorite_movies",
+		"users",
+		"user_favorite_movies",
+	}
+	if got := r.take(); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	if alice.ID == 0 || bob.ID == 0 || shared.Build().ID == 0 {
+		t.Error("ids were not bound from RETURNING")
+	}
+}
+
+const fixtureYAML = `
+users:
+  - ref: alice
+    name: Alice
+    email: alice@example.com
+  - name: Bob
+movies:
+  - ref: matrix
+    title: The Matrix
+    release_year: 1999
+  - ref: alien
+books:
+  - ref: dune
+    title: Dune
+    published_year: 1965
+favorites:
+  - user: alice
+    movie: matrix
+  - user: alice
+    movie: alien
+`
+
+func TestPersistFixtures(t *testing.T) {
+	t.Parallel()
+
+	file, err := ParseFixtures(strings.NewReader(fixtureYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, r := openRecorder(t)
+	fx, err := NewWithSeed(1).Persist(context.Background(), db, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"users", "users", "movies", "movies", "books", "user_favorite_movies", "user_favorite_movies"}
+	if got := r.take(); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	if u := fx.Users["alice"]; u.Email != "alice@example.com" || u.Name != "Alice" {
+		t.Errorf("unexpected user %#v", u)
+	}
+	if m := fx.Movies["matrix"]; m.Title != "The Matrix" || m.ReleaseYear.Int != 1999 {
+		t.Errorf("unexpected movie %#v", m)
+	}
+	if b := fx.Books["dune"]; b.Title != "Dune" || len(b.AuthorName) == 0 {
+		t.Errorf("missing columns should be filled by the factory: %#v", b)
+	}
+	if len(fx.Favorites) != 2 || fx.Favorites[1].UserID != fx.Users["alice"].ID || fx.Favorites[1].MovieID != fx.Movies["alien"].ID {
+		t.Errorf("unexpected favorites %#v", fx.Favorites)
+	}
+}
+
+func TestParseFixturesErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"unknown user":  "favorites:\n  - user: nobody\n    movie: x\n",
+		"unknown movie": "users:\n  - ref: a\nfavorites:\n  - user: a\n    movie: x\n",
+		"duplicate ref": "movies:\n  - ref: a\n  - ref: a\n",
+		"unknown field": "books:\n  - isbn: 123\n",
+	}
+
+	for name, in := range tests {
+		if _, err := ParseFixtures(strings.NewReader(in)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestLoadFixtures(t *testing.T) {
+	t.Parallel()
+
+	db, _ := openRecorder(t)
+	fx, err := New().LoadFixtures(context.Background(), db, "testdata/favorites.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fx.Users) != 1 || len(fx.Movies) != 3 || len(fx.Favorites) != 3 {
+		t.Errorf("unexpected fixtures %#v", fx)
+	}
+}
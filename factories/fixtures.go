@@ -0,0 +1,234 @@
+package factories
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"gopkg.in/yaml.v3"
+
+	"sqlboiler-project/models"
+)
+
+// FixtureFile は YAML のフィクスチャファイルの内容。
+// ref を付けた行は favorites から参照できる。省略したカラムは Factory が埋める。
+//
+//	users:
+//	  - ref: alice
+//	    name: Alice
+//	    email: alice@example.com
+//	movies:
+//	  - ref: matrix
+//	    title: The Matrix
+//	    release_year: 1999
+//	books:
+//	  - title: Dune
+//	    author: Frank Herbert
+//	    published_year: 1965
+//	favorites:
+//	  - user: alice
+//	    movie: matrix
+type FixtureFile struct {
+	Users     []UserFixture     `yaml:"users"`
+	Movies    []MovieFixture    `yaml:"movies"`
+	Books     []BookFixture     `yaml:"books"`
+	Favorites []FavoriteFixture `yaml:"favorites"`
+}
+
+// UserFixture は users の 1 行
+type UserFixture struct {
+	Ref   string `yaml:"ref"`
+	Name  string `yaml:"name"`
+	Email string `yaml:"email"`
+}
+
+// MovieFixture は movies の 1 行
+type MovieFixture struct {
+	Ref         string `yaml:"ref"`
+	Title       string `yaml:"title"`
+	ReleaseYear *int   `yaml:"release_year"`
+}
+
+// BookFixture は books の 1 行
+type BookFixture struct {
+	Ref           string `yaml:"ref"`
+	Title         string `yaml:"title"`
+	Author        string `yaml:"author"`
+	PublishedYear *int   `yaml:"published_year"`
+}
+
+// FavoriteFixture は user_favorite_movies の 1 行。ユーザーと映画は ref で指定する。
+type FavoriteFixture struct {
+	User  string `yaml:"user"`
+	Movie string `yaml:"movie"`
+}
+
+// Fixtures は保存したフィクスチャ。ref をキーに保存後のモデルを引ける。
+type Fixtures struct {
+	Users     map[string]*models.User
+	Movies    map[string]*models.Movie
+	Books     map[string]*models.Book
+	Favorites models.UserFavoriteMovieSlice
+}
+
+// ParseFixtures は YAML を読み込み、ref の重複や未定義の参照を検査する
+func ParseFixtures(r io.Reader) (*FixtureFile, error) {
+	file := &FixtureFile{}
+
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(file); err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "factories: unable to parse fixtures")
+	}
+
+	if err := file.validate(); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (file *FixtureFile) validate() error {
+	users := map[string]bool{}
+	for _, u := range file.Users {
+		if err := addRef(users, "user", u.Ref); err != nil {
+			return err
+		}
+	}
+
+	movies := map[string]bool{}
+	for _, m := range file.Movies {
+		if err := addRef(movies, "movie", m.Ref); err != nil {
+			return err
+		}
+	}
+
+	books := map[string]bool{}
+	for _, b := range file.Books {
+		if err := addRef(books, "book", b.Ref); err != nil {
+			return err
+		}
+	}
+
+	for i, fav := range file.Favorites {
+		if !users[fav.User] {
+			return errors.Errorf("factories: favorites[%d] refers to unknown user %q", i, fav.User)
+		}
+		if !movies[fav.Movie] {
+			return errors.Errorf("factories: favorites[%d] refers to unknown movie %q", i, fav.Movie)
+		}
+	}
+
+	return nil
+}
+
+func addRef(refs map[string]bool, kind, ref string) error {
+	if len(ref) == 0 {
+		return nil
+	}
+	if refs[ref] {
+		return errors.Errorf("factories: duplicate %s ref %q", kind, ref)
+	}
+	refs[ref] = true
+
+	return nil
+}
+
+// LoadFixtures は path の YAML を読み込んで保存する
+func (f *Factory) LoadFixtures(ctx context.Context, exec boil.ContextExecutor, path string) (*Fixtures, error) {
+	r, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "factories: unable to open fixtures")
+	}
+	defer r.Close()
+
+	file, err := ParseFixtures(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", path)
+	}
+
+	return f.Persist(ctx, exec, file)
+}
+
+// Persist はユーザー、映画、本、お気に入りの順（依存順）に保存する。
+// 途中で失敗した場合に備えて exec にはトランザクションを渡すこと。
+func (f *Factory) Persist(ctx context.Context, exec boil.ContextExecutor, file *FixtureFile) (*Fixtures, error) {
+	ret := &Fixtures{
+		Users:  map[string]*models.User{},
+		Movies: map[string]*models.Movie{},
+		Books:  map[string]*models.Book{},
+	}
+
+	users := map[string]*UserBuilder{}
+	for _, u := range file.Users {
+		b := f.User()
+		if len(u.Name) != 0 {
+			b.Name(u.Name)
+		}
+		if len(u.Email) != 0 {
+			b.Email(u.Email)
+		}
+
+		o, err := b.Create(ctx, exec)
+		if err != nil {
+			return nil, err
+		}
+		if len(u.Ref) != 0 {
+			users[u.Ref] = b
+			ret.Users[u.Ref] = o
+		}
+	}
+
+	movies := map[string]*MovieBuilder{}
+	for _, m := range file.Movies {
+		b := f.Movie()
+		if len(m.Title) != 0 {
+			b.Title(m.Title)
+		}
+		if m.ReleaseYear != nil {
+			b.ReleaseYear(*m.ReleaseYear)
+		}
+
+		o, err := b.Create(ctx, exec)
+		if err != nil {
+			return nil, err
+		}
+		if len(m.Ref) != 0 {
+			movies[m.Ref] = b
+			ret.Movies[m.Ref] = o
+		}
+	}
+
+	for _, bk := range file.Books {
+		b := f.Book()
+		if len(bk.Title) != 0 {
+			b.Title(bk.Title)
+		}
+		if len(bk.Author) != 0 {
+			b.Author(bk.Author)
+		}
+		if bk.PublishedYear != nil {
+			b.PublishedYear(*bk.PublishedYear)
+		}
+
+		o, err := b.Create(ctx, exec)
+		if err != nil {
+			return nil, err
+		}
+		if len(bk.Ref) != 0 {
+			ret.Books[bk.Ref] = o
+		}
+	}
+
+	for _, fav := range file.Favorites {
+		o, err := f.UserFavoriteMovie().User(users[fav.User]).Movie(movies[fav.Movie]).Create(ctx, exec)
+		if err != nil {
+			return nil, err
+		}
+		ret.Favorites = append(ret.Favorites, o)
+	}
+
+	return ret, nil
+}
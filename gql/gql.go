@@ -0,0 +1,28 @@
+// Package gql は生成されたモデルの上に GraphQL の API を提供する。
+// スキーマは schema.graphql にあり、本・ユーザー・映画の読み取りと本の作成・更新・削除を扱う。
+package gql
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+//go:embed schema.graphql
+var schemaString string
+
+// クエリのネストの深さの上限。favoriteMovies より深いネストは無いので余裕を持たせた値。
+const maxDepth = 10
+
+// NewSchema は exec でクエリを実行するスキーマを返す
+func NewSchema(exec boil.ContextExecutor) (*graphql.Schema, error) {
+	return graphql.ParseSchema(schemaString, &Resolver{exec: exec}, graphql.MaxDepth(maxDepth))
+}
+
+// Handler は POST された GraphQL のリクエストを実行する http.Handler を返す
+func Handler(schema *graphql.Schema) http.Handler {
+	return &relay.Handler{Schema: schema}
+}
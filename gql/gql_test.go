@@ -0,0 +1,250 @@
+package gql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"sqlboiler-project/internal/testdb"
+	"sqlboiler-project/models"
+)
+
+// openFake は 3 人のユーザーと 2 本の映画、3 件のお気に入りを返す Fake を開く
+func openFake(t *testing.T) (*sql.DB, *testdb.Fake) {
+	t.Helper()
+
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	return testdb.OpenFake(t, map[string]testdb.Table{
+		"users": {
+			Cols: []string{"id", "name", "email", "created_at", "tenant_id"},
+			Rows: [][]driver.Value{
+				{int64(1), "Alice", "alice@example.com", at, int64(0)},
+				{int64(2), "Bob", "bob@example.com", nil, int64(0)},
+				{int64(3), "Carol", "carol@example.com", nil, int64(0)},
+			},
+		},
+		"user_favorite_movies": {
+			Cols: []string{"user_id", "movie_id", "created_at"},
+			Rows: [][]driver.Value{
+				{int64(1), int64(2), at},
+				{int64(1), int64(1), at},
+				{int64(2), int64(2), nil},
+			},
+		},
+		"movies": {
+			Cols: []string{"id", "title", "release_year", "created_at", "tenant_id"},
+			Rows: [][]driver.Value{
+				{int64(1), "Solaris", int64(1972), nil, int64(0)},
+				{int64(2), "Stalker", nil, nil, int64(0)},
+			},
+		},
+	})
+}
+
+func TestUsersFavoriteMoviesBatched(t *testing.T) {
+	t.Parallel()
+
+	db, f := openFake(t)
+	schema, err := NewSchema(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := schema.Exec(context.Background(), `{
+		users(first: 2) {
+			edges { node { id name favoriteMovies { movie { title releaseYear } favoritedAt } } }
+			pageInfo { endCursor hasNextPage }
+		}
+	}`, "", nil)
+	if len(resp.Errors) != 0 {
+		t.Fatal(resp.Errors)
+	}
+
+	want := `{"users":{"edges":[` +
+		`{"node":{"id":"1","name":"Alice","favoriteMovies":[` +
+		`{"movie":{"title":"Stalker","releaseYear":null},"favoritedAt":"2024-03-01T00:00:00Z"},` +
+		`{"movie":{"title":"Solaris","releaseYear":1972},"favoritedAt":"2024-03-01T00:00:00Z"}]}},` +
+		`{"node":{"id":"2","name":"Bob","favoriteMovies":[{"movie":{"title":"Stalker","releaseYear":null},"favoritedAt":null}]}}],` +
+		`"pageInfo":{"endCursor":"` + encodeCursor(2) + `","hasNextPage":true}}}`
+	if string(resp.Data) != want {
+		t.Errorf("got  %s\nwant %s", resp.Data, want)
+	}
+
+	// ユーザー・お気に入り・映画の 3 回だけで、ユーザーの数に比例しない
+	got := f.Take()
+	if len(got) != 3 {
+		t.Fatalf("want 3 queries, got %d: %v", len(got), got)
+	}
+	if !strings.Contains(got[1], `"user_favorite_movies"."user_id" IN ($1,$2)`) {
+		t.Errorf("favorites should be loaded for the page only: %s", got[1])
+	}
+	if !strings.Contains(got[1], `ORDER BY user_favorite_movies.created_at DESC`) {
+		t.Errorf("favorites should be ordered newest first: %s", got[1])
+	}
+}
+
+func TestCreateBookValidation(t *testing.T) {
+	t.Parallel()
+
+	db, f := openFake(t)
+	schema, err := NewSchema(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query string
+		field string
+	}{
+		{`mutation { createBook(input: {title: "  ", authorName: "Lem"}) { id } }`, "title"},
+		{`mutation { createBook(input: {title: "Solaris", authorName: "` + strings.Repeat("x", 256) + `"}) { id } }`, "authorName"},
+		{`mutation { createBook(input: {title: "Solaris", authorName: "Lem", publishedYear: 0}) { id } }`, "publishedYear"},
+		{`mutation { updateBook(id: "1", input: {title: ""}) { id } }`, "title"},
+	}
+
+	for _, tt := range tests {
+		resp := schema.Exec(context.Background(), tt.query, "", nil)
+		if len(resp.Errors) != 1 {
+			t.Fatalf("%s: want 1 error, got %v", tt.field, resp.Errors)
+		}
+		ext := resp.Errors[0].Extensions
+		if ext["code"] != "VALIDATION_FAILED" || ext["field"] != tt.field {
+			t.Errorf("%s: extensions = %v", tt.field, ext)
+		}
+	}
+
+	if q := f.Take(); len(q) != 0 {
+		t.Errorf("invalid input must not reach the database: %v", q)
+	}
+}
+
+func TestBookWhereMods(t *testing.T) {
+	t.Parallel()
+
+	var where bookWhere
+	if err := json.Unmarshal([]byte(`{"TitleContains": "100%_go", "PublishedYearGte": 2000, "PublishedYearIsNull": false}`), &where); err != nil {
+		t.Fatal(err)
+	}
+
+	mods, err := where.mods()
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, args := queries.BuildQuery(models.Books(mods...).Query)
+
+	for _, want := range []string{
+		`"books"."title" ILIKE $1`,
+		`"books"."published_year" >= $2`,
+		`"books"."published_year" is not null`,
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("missing %q in %s", want, query)
+		}
+	}
+	if len(args) != 2 || args[0] != `%100\%\_go%` {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+
+	id, err := decodeCursor(encodeCursor(42))
+	if err != nil || id != 42 {
+		t.Errorf("round trip: %d, %v", id, err)
+	}
+	for _, c := range []string{"", "!!", encodeCursor(1)[1:]} {
+		if _, err := decodeCursor(c); err == nil {
+			t.Errorf("decodeCursor(%q): want an error", c)
+		}
+	}
+
+	for _, first := range []int32{0, maxFirst + 1} {
+		if _, _, err := (pageArgs{First: first}).mods("id"); err == nil {
+			t.Errorf("first %d: want an error", first)
+		}
+	}
+}
+
+// mustExec は query を実行し、エラーが無いことを確かめて data を返す
+func mustExec(t *testing.T, schema *graphql.Schema, query string) string {
+	t.Helper()
+
+	resp := schema.Exec(context.Background(), query, "", nil)
+	if len(resp.Errors) != 0 {
+		t.Fatalf("%s: %v", query, resp.Errors)
+	}
+
+	return string(resp.Data)
+}
+
+func TestBooksWithDatabase(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.Open(t)
+	schema, err := NewSchema(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	got := mustExec(t, schema, `mutation { createBook(input: {title: "Solaris", authorName: " Stanisław Lem ", publishedYear: 1961}) { id title authorName publishedYear } }`)
+	if want := `{"createBook":{"id":"1","title":"Solaris","authorName":"Stanisław Lem","publishedYear":1961}}`; got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+	mustExec(t, schema, `mutation { createBook(input: {title: "100% Lem", authorName: "stanisław  lem"}) { id } }`)
+	mustExec(t, schema, `mutation { createBook(input: {title: "1000 Cranes", authorName: "Anonymous"}) { id } }`)
+
+	// 表記ゆれのある著者名は同じ著者にまとめる
+	books, err := models.Books(qm.OrderBy(models.BookColumns.ID)).All(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(books) != 3 || !books[0].AuthorID.Valid || books[0].AuthorID != books[1].AuthorID || books[0].AuthorID == books[2].AuthorID {
+		t.Fatalf("author ids: %v", bookAuthorIDs(books))
+	}
+
+	// % は文字どおりに扱う
+	got = mustExec(t, schema, `{ books(where: {titleContains: "100%"}) { edges { node { title } } } }`)
+	if want := `{"books":{"edges":[{"node":{"title":"100% Lem"}}]}}`; got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	got = mustExec(t, schema, `mutation { updateBook(id: "1", input: {authorName: "Ursula K. Le Guin"}) { authorName } }`)
+	if want := `{"updateBook":{"authorName":"Ursula K. Le Guin"}}`; got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+	if err := books[0].Reload(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	author, err := books[0].Author().One(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if author.Name != "Ursula K. Le Guin" {
+		t.Errorf("author = %q", author.Name)
+	}
+
+	got = mustExec(t, schema, `mutation { a: deleteBook(id: "1") b: deleteBook(id: "1") }`)
+	if want := `{"a":true,"b":false}`; got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func bookAuthorIDs(books models.BookSlice) []null.Int {
+	ids := make([]null.Int, len(books))
+	for i, b := range books {
+		ids[i] = b.AuthorID
+	}
+
+	return ids
+}
@@ -0,0 +1,52 @@
+package gql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"sqlboiler-project/models"
+)
+
+// favoritesBatch は同じ一覧で読んだユーザーのお気に入り映画を、最初に必要になったときにまとめて読む。
+// ユーザーごとに読むと一覧の件数だけクエリが増える（N+1）ので、生成コードの Load でユーザー全員分を 2 回のクエリで読む。
+type favoritesBatch struct {
+	exec  boil.ContextExecutor
+	users models.UserSlice
+
+	once sync.Once
+	err  error
+}
+
+func (b *favoritesBatch) load(ctx context.Context) error {
+	b.once.Do(func() {
+		b.err = loadFavoriteMovies(ctx, b.exec, b.users)
+	})
+
+	return b.err
+}
+
+// loadFavoriteMovies は users の R.UserFavoriteMovies とその R.Movie を読む。お気に入りは登録の新しい順に並べる。
+func loadFavoriteMovies(ctx context.Context, exec boil.ContextExecutor, users models.UserSlice) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	slice := []*models.User(users)
+	order := qm.OrderBy(models.UserFavoriteMovieTableColumns.CreatedAt + " DESC, " + models.UserFavoriteMovieTableColumns.MovieID + " ASC")
+	if err := users[0].L.LoadUserFavoriteMovies(ctx, exec, false, &slice, order); err != nil {
+		return err
+	}
+
+	var favs []*models.UserFavoriteMovie
+	for _, u := range users {
+		favs = append(favs, u.R.UserFavoriteMovies...)
+	}
+	if len(favs) == 0 {
+		return nil
+	}
+
+	return favs[0].L.LoadMovie(ctx, exec, false, &favs, nil)
+}
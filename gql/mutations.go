@@ -0,0 +1,196 @@
+package gql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/friendsofgo/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"sqlboiler-project/database"
+	"sqlboiler-project/models"
+)
+
+// ValidationError は入力値の検証エラー。GraphQL のエラーの extensions に code と field を載せる。
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("gql: %s %s", e.Field, e.Message)
+}
+
+// Extensions はエラーの extensions を返す（graphql-go が参照する）
+func (e *ValidationError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": "VALIDATION_FAILED", "field": e.Field}
+}
+
+// validationError は models の検証エラーを extensions 付きの ValidationError にする
+func validationError(err error) error {
+	var ve *models.ValidationError
+	if errors.As(err, &ve) {
+		return &ValidationError{Field: ve.Field, Message: ve.Message}
+	}
+
+	return err
+}
+
+// validateText は必須の文字列を models.ValidateText で検証する
+func validateText(field, s string) (string, error) {
+	s, err := models.ValidateText(field, s)
+	return s, validationError(err)
+}
+
+// validateYear は出版年を models.ValidateYear で検証する。year が無ければ NULL にする。
+func validateYear(field string, year *int32) (null.Int, error) {
+	var v null.Int
+	if year != nil {
+		v = null.IntFrom(int(*year))
+	}
+
+	return v, validationError(models.ValidateYear(field, v))
+}
+
+// bookInput はスキーマの BookInput
+type bookInput struct {
+	Title         string
+	AuthorName    string
+	PublishedYear *int32
+}
+
+// bookPatch はスキーマの BookPatch。null のフィールドは変えない。
+type bookPatch struct {
+	Title         *string
+	AuthorName    *string
+	PublishedYear *int32
+}
+
+// CreateBook は本を検証して作成する
+func (r *Resolver) CreateBook(ctx context.Context, args struct{ Input bookInput }) (*bookResolver, error) {
+	b := &models.Book{}
+
+	var err error
+	if b.Title, err = validateText("title", args.Input.Title); err != nil {
+		return nil, err
+	}
+	authorName, err := validateText("authorName", args.Input.AuthorName)
+	if err != nil {
+		return nil, err
+	}
+	if b.PublishedYear, err = validateYear("publishedYear", args.Input.PublishedYear); err != nil {
+		return nil, err
+	}
+
+	// 著者を作ってから本の INSERT に失敗したときに著者だけが残らないようにする
+	err = database.InTx(ctx, r.exec, func(exec boil.ContextExecutor) error {
+		if err := b.AssignAuthor(ctx, exec, authorName); err != nil {
+			return err
+		}
+		return b.Insert(ctx, exec, boil.Infer())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &bookResolver{b: b}, nil
+}
+
+// UpdateBook は input で指定されたフィールドだけを検証して更新する
+func (r *Resolver) UpdateBook(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input bookPatch
+}) (*bookResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 検証は読み込む前に済ませる
+	var cols []string
+	var title, authorName string
+	var year null.Int
+	if args.Input.Title != nil {
+		if title, err = validateText("title", *args.Input.Title); err != nil {
+			return nil, err
+		}
+		cols = append(cols, models.BookColumns.Title)
+	}
+	if args.Input.AuthorName != nil {
+		if authorName, err = validateText("authorName", *args.Input.AuthorName); err != nil {
+			return nil, err
+		}
+		cols = append(cols, models.BookColumns.AuthorName, models.BookColumns.AuthorID)
+	}
+	if args.Input.PublishedYear != nil {
+		if year, err = validateYear("publishedYear", args.Input.PublishedYear); err != nil {
+			return nil, err
+		}
+		cols = append(cols, models.BookColumns.PublishedYear)
+	}
+
+	b, err := models.FindBook(ctx, r.exec, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Errorf("gql: book %d not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(cols) == 0 {
+		return &bookResolver{b: b}, nil
+	}
+
+	b.Title = stringOr(args.Input.Title, title, b.Title)
+	if args.Input.PublishedYear != nil {
+		b.PublishedYear = year
+	}
+
+	err = database.InTx(ctx, r.exec, func(exec boil.ContextExecutor) error {
+		if args.Input.AuthorName != nil {
+			if err := b.AssignAuthor(ctx, exec, authorName); err != nil {
+				return err
+			}
+		}
+		_, err := b.Update(ctx, exec, boil.Whitelist(cols...))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &bookResolver{b: b}, nil
+}
+
+// stringOr は入力があれば検証済みの値を、無ければ現在の値を返す
+func stringOr(input *string, validated, current string) string {
+	if input == nil {
+		return current
+	}
+	return validated
+}
+
+// DeleteBook は本を削除する。削除した本があれば true を返す。
+func (r *Resolver) DeleteBook(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	b, err := models.FindBook(ctx, r.exec, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	rowsAff, err := b.Delete(ctx, r.exec)
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAff != 0, nil
+}
@@ -0,0 +1,110 @@
+package gql
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// 一覧で 1 回に返す件数の上限
+const maxFirst = 100
+
+// cursorPrefix はカーソルの中身の接頭辞。カーソルは id 順のページングの位置（直前の行の id）を持つ。
+const cursorPrefix = "id:"
+
+// pageArgs は一覧の first / after 引数。first はスキーマで既定値を指定しているので必ず入る。
+type pageArgs struct {
+	First int32
+	After *string
+}
+
+// encodeCursor は id の行の直後から読むカーソルを返す
+func encodeCursor(id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(id)))
+}
+
+// decodeCursor はカーソルが指す id を返す
+func decodeCursor(cursor string) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(b), cursorPrefix) {
+		return 0, errors.Errorf("gql: invalid cursor %q", cursor)
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(string(b), cursorPrefix))
+	if err != nil {
+		return 0, errors.Errorf("gql: invalid cursor %q", cursor)
+	}
+
+	return id, nil
+}
+
+// mods は idColumn 順に after の次から first 件を読むクエリ修飾子と first を返す。
+// 次のページがあるかを調べるため 1 件多く読む。
+func (a pageArgs) mods(idColumn string) ([]qm.QueryMod, int, error) {
+	first := int(a.First)
+	if first < 1 || first > maxFirst {
+		return nil, 0, errors.Errorf("gql: first must be between 1 and %d", maxFirst)
+	}
+
+	mods := []qm.QueryMod{qm.OrderBy(idColumn + " ASC"), qm.Limit(first + 1)}
+	if a.After != nil {
+		after, err := decodeCursor(*a.After)
+		if err != nil {
+			return nil, 0, err
+		}
+		mods = append(mods, qm.Where(fmt.Sprintf("%s > ?", idColumn), after))
+	}
+
+	return mods, first, nil
+}
+
+// connection は Relay 形式の一覧
+type connection[T any] struct {
+	edges    []*edge[T]
+	pageInfo *pageInfo
+}
+
+func (c *connection[T]) Edges() []*edge[T] { return c.edges }
+
+func (c *connection[T]) PageInfo() *pageInfo { return c.pageInfo }
+
+type edge[T any] struct {
+	cursor string
+	node   T
+}
+
+func (e *edge[T]) Cursor() string { return e.cursor }
+
+func (e *edge[T]) Node() T { return e.node }
+
+type pageInfo struct {
+	endCursor   *string
+	hasNextPage bool
+}
+
+func (p *pageInfo) EndCursor() *string { return p.endCursor }
+
+func (p *pageInfo) HasNextPage() bool { return p.hasNextPage }
+
+// newConnection は mods で読んだ nodes（最大 first+1 件）から一覧を作る。id は各 node の id を返す。
+func newConnection[T any](nodes []T, first int, id func(T) int) *connection[T] {
+	c := &connection[T]{edges: []*edge[T]{}, pageInfo: &pageInfo{}}
+	if len(nodes) > first {
+		nodes = nodes[:first]
+		c.pageInfo.hasNextPage = true
+	}
+
+	for _, n := range nodes {
+		c.edges = append(c.edges, &edge[T]{cursor: encodeCursor(id(n)), node: n})
+	}
+	if len(c.edges) != 0 {
+		end := c.edges[len(c.edges)-1].cursor
+		c.pageInfo.endCursor = &end
+	}
+
+	return c
+}
@@ -0,0 +1,247 @@
+package gql
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"sqlboiler-project/models"
+)
+
+// Resolver はスキーマの Query と Mutation を解決する
+type Resolver struct {
+	exec boil.ContextExecutor
+}
+
+// parseID は GraphQL の ID を主キーにする
+func parseID(id graphql.ID) (int, error) {
+	n, err := strconv.Atoi(string(id))
+	if err != nil {
+		return 0, errors.Errorf("gql: invalid id %q", id)
+	}
+
+	return n, nil
+}
+
+func formatID(id int) graphql.ID {
+	return graphql.ID(strconv.Itoa(id))
+}
+
+func nullInt32(v null.Int) *int32 {
+	if !v.Valid {
+		return nil
+	}
+	i := int32(v.Int)
+	return &i
+}
+
+func nullTimeString(v null.Time) *string {
+	if !v.Valid {
+		return nil
+	}
+	s := v.Time.Format(time.RFC3339)
+	return &s
+}
+
+// Book は id の本を返す。無ければ null を返す。
+func (r *Resolver) Book(ctx context.Context, args struct{ ID graphql.ID }) (*bookResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := models.FindBook(ctx, r.exec, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &bookResolver{b: b}, nil
+}
+
+// Books は where に合う本を id 順に返す
+func (r *Resolver) Books(ctx context.Context, args struct {
+	Where *bookWhere
+	First int32
+	After *string
+}) (*connection[*bookResolver], error) {
+	mods, first, err := pageArgs{First: args.First, After: args.After}.mods(models.BookTableColumns.ID)
+	if err != nil {
+		return nil, err
+	}
+	if args.Where != nil {
+		where, err := args.Where.mods()
+		if err != nil {
+			return nil, err
+		}
+		mods = append(where, mods...)
+	}
+
+	books, err := models.Books(mods...).All(ctx, r.exec)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*bookResolver, len(books))
+	for i, b := range books {
+		nodes[i] = &bookResolver{b: b}
+	}
+
+	return newConnection(nodes, first, func(n *bookResolver) int { return n.b.ID }), nil
+}
+
+// User は id のユーザーを返す。無ければ null を返す。
+func (r *Resolver) User(ctx context.Context, args struct{ ID graphql.ID }) (*userResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := models.FindUser(ctx, r.exec, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newUserResolvers(r.exec, models.UserSlice{u})[0], nil
+}
+
+// Users はユーザーを id 順に返す
+func (r *Resolver) Users(ctx context.Context, args pageArgs) (*connection[*userResolver], error) {
+	mods, first, err := args.mods(models.UserTableColumns.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := models.Users(mods...).All(ctx, r.exec)
+	if err != nil {
+		return nil, err
+	}
+	// 次のページの判定用に 1 件多く読んでいるが、お気に入り映画はこのページの分だけ読む
+	hasNextPage := len(users) > first
+	if hasNextPage {
+		users = users[:first]
+	}
+
+	conn := newConnection(newUserResolvers(r.exec, users), first, func(n *userResolver) int { return n.u.ID })
+	conn.pageInfo.hasNextPage = hasNextPage
+
+	return conn, nil
+}
+
+// Movie は id の映画を返す。無ければ null を返す。
+func (r *Resolver) Movie(ctx context.Context, args struct{ ID graphql.ID }) (*movieResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := models.FindMovie(ctx, r.exec, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &movieResolver{m: m}, nil
+}
+
+// Movies は映画を id 順に返す
+func (r *Resolver) Movies(ctx context.Context, args pageArgs) (*connection[*movieResolver], error) {
+	mods, first, err := args.mods(models.MovieTableColumns.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	movies, err := models.Movies(mods...).All(ctx, r.exec)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*movieResolver, len(movies))
+	for i, m := range movies {
+		nodes[i] = &movieResolver{m: m}
+	}
+
+	return newConnection(nodes, first, func(n *movieResolver) int { return n.m.ID }), nil
+}
+
+type bookResolver struct {
+	b *models.Book
+}
+
+func (r *bookResolver) ID() graphql.ID { return formatID(r.b.ID) }
+
+func (r *bookResolver) Title() string { return r.b.Title }
+
+func (r *bookResolver) AuthorName() string { return r.b.AuthorName }
+
+func (r *bookResolver) PublishedYear() *int32 { return nullInt32(r.b.PublishedYear) }
+
+type movieResolver struct {
+	m *models.Movie
+}
+
+func (r *movieResolver) ID() graphql.ID { return formatID(r.m.ID) }
+
+func (r *movieResolver) Title() string { return r.m.Title }
+
+func (r *movieResolver) ReleaseYear() *int32 { return nullInt32(r.m.ReleaseYear) }
+
+type userResolver struct {
+	u     *models.User
+	batch *favoritesBatch
+}
+
+// newUserResolvers は users をまとめてお気に入り映画を読む userResolver にする
+func newUserResolvers(exec boil.ContextExecutor, users models.UserSlice) []*userResolver {
+	batch := &favoritesBatch{exec: exec, users: users}
+
+	nodes := make([]*userResolver, len(users))
+	for i, u := range users {
+		nodes[i] = &userResolver{u: u, batch: batch}
+	}
+
+	return nodes
+}
+
+func (r *userResolver) ID() graphql.ID { return formatID(r.u.ID) }
+
+func (r *userResolver) Name() string { return r.u.Name }
+
+func (r *userResolver) CreatedAt() *string { return nullTimeString(r.u.CreatedAt) }
+
+// FavoriteMovies は同じ一覧のユーザーの分とまとめて読んだお気に入り映画を返す
+func (r *userResolver) FavoriteMovies(ctx context.Context) ([]*favoriteMovieResolver, error) {
+	if err := r.batch.load(ctx); err != nil {
+		return nil, err
+	}
+
+	favs := []*favoriteMovieResolver{}
+	for _, f := range r.u.R.UserFavoriteMovies {
+		if f.R != nil && f.R.Movie != nil {
+			favs = append(favs, &favoriteMovieResolver{f: f})
+		}
+	}
+
+	return favs, nil
+}
+
+type favoriteMovieResolver struct {
+	f *models.UserFavoriteMovie
+}
+
+func (r *favoriteMovieResolver) Movie() *movieResolver { return &movieResolver{m: r.f.R.Movie} }
+
+func (r *favoriteMovieResolver) FavoritedAt() *string { return nullTimeString(r.f.CreatedAt) }
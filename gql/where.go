@@ -0,0 +1,73 @@
+package gql
+
+import (
+	"github.com/graph-gophers/graphql-go"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"sqlboiler-project/models"
+)
+
+// bookWhere はスキーマの BookWhere。各条件を models.BookWhere のヘルパーにする。
+type bookWhere struct {
+	IDIn                *[]graphql.ID
+	Title               *string
+	TitleContains       *string
+	AuthorName          *string
+	AuthorID            *graphql.ID
+	PublishedYear       *int32
+	PublishedYearGte    *int32
+	PublishedYearLte    *int32
+	PublishedYearIsNull *bool
+}
+
+// mods は指定された条件をクエリ修飾子にする
+func (w *bookWhere) mods() ([]qm.QueryMod, error) {
+	var mods []qm.QueryMod
+
+	if w.IDIn != nil {
+		ids := make([]int, len(*w.IDIn))
+		for i, id := range *w.IDIn {
+			n, err := parseID(id)
+			if err != nil {
+				return nil, err
+			}
+			ids[i] = n
+		}
+		mods = append(mods, models.BookWhere.ID.IN(ids))
+	}
+	if w.Title != nil {
+		mods = append(mods, models.BookWhere.Title.EQ(*w.Title))
+	}
+	if w.TitleContains != nil {
+		mods = append(mods, models.BookWhere.Title.ILIKE(models.ContainsPattern(*w.TitleContains)))
+	}
+	if w.AuthorName != nil {
+		mods = append(mods, models.BookWhere.AuthorName.EQ(*w.AuthorName))
+	}
+	if w.AuthorID != nil {
+		id, err := parseID(*w.AuthorID)
+		if err != nil {
+			return nil, err
+		}
+		mods = append(mods, models.BookWhere.AuthorID.EQ(null.IntFrom(id)))
+	}
+	if w.PublishedYear != nil {
+		mods = append(mods, models.BookWhere.PublishedYear.EQ(null.IntFrom(int(*w.PublishedYear))))
+	}
+	if w.PublishedYearGte != nil {
+		mods = append(mods, models.BookWhere.PublishedYear.GTE(null.IntFrom(int(*w.PublishedYearGte))))
+	}
+	if w.PublishedYearLte != nil {
+		mods = append(mods, models.BookWhere.PublishedYear.LTE(null.IntFrom(int(*w.PublishedYearLte))))
+	}
+	if w.PublishedYearIsNull != nil {
+		if *w.PublishedYearIsNull {
+			mods = append(mods, models.BookWhere.PublishedYear.IsNull())
+		} else {
+			mods = append(mods, models.BookWhere.PublishedYear.IsNotNull())
+		}
+	}
+
+	return mods, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"sqlboiler-project/config"
+	"sqlboiler-project/database"
+	"sqlboiler-project/gql"
+	"sqlboiler-project/tenant"
+)
+
+// ヘルスチェックのタイムアウト
+const healthTimeout = 2 * time.Second
+
+// runGraphQL は graphql サブコマンド。/graphql で GraphQL を、/healthz でヘルスチェックを返す。終了コードを返す。
+func runGraphQL(args []string) int {
+	fs := flag.NewFlagSet("graphql", flag.ContinueOnError)
+	flags := config.RegisterFlags(fs)
+	addr := fs.String("addr", ":8080", "Address to listen on")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	// サーバーは設定ファイルを監視し、[app] の変更を再起動せずに反映する
+	w, err := config.Watch(flags)
+	if err != nil {
+		log.Printf("設定読み込みエラー: %v\n", err)
+		return 2
+	}
+	cfg := w.Config()
+	boil.DebugMode = cfg.App.Debug
+
+	ctx, stop := database.SignalContext(context.Background())
+	defer stop()
+
+	db, err := database.Open(ctx, &cfg)
+	if err != nil {
+		log.Printf("データベース接続エラー: %v\n", err)
+		return 1
+	}
+	defer database.Shutdown(db, shutdownTimeout)
+
+	exec := database.NewExecutor(db, cfg.App.Timeouts)
+	w.OnChange(func(app config.App) {
+		exec.SetTimeouts(app.Timeouts)
+		log.Println("設定を再読み込みしました")
+	})
+
+	schema, err := gql.NewSchema(exec)
+	if err != nil {
+		log.Printf("GraphQL スキーマエラー: %v\n", err)
+		return 1
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", withApp(w, gql.Handler(schema)))
+	mux.Handle("/healthz", database.HealthHandler(db, healthTimeout))
+	srv := &http.Server{Addr: *addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	// SIGINT/SIGTERM で受け付けを止め、処理中のリクエストを待ってから終了する
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("GraphQL サーバーを起動しました: http://%s/graphql\n", *addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("サーバーエラー: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// withApp は再読み込みした [app] の設定をリクエストの context に入れる
+func withApp(w *config.Watcher, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(rw, r.WithContext(appContext(r.Context(), w.App())))
+	})
+}
+
+// appContext は app.tenant_id のテナントと app.debug を ctx に載せる。
+// boil.DebugMode は実行中に書き換えると処理中のクエリと競合するので、起動時の値のままにする。
+func appContext(ctx context.Context, app config.App) context.Context {
+	return boil.WithDebug(tenant.WithTenant(ctx, app.TenantID), app.Debug)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"sqlboiler-project/config"
+	"sqlboiler-project/database"
+	"sqlboiler-project/grpcserver"
+)
+
+// runGRPC は grpc サブコマンド。CatalogService と標準のヘルスチェック・リフレクションを提供する。終了コードを返す。
+func runGRPC(args []string) int {
+	fs := flag.NewFlagSet("grpc", flag.ContinueOnError)
+	flags := config.RegisterFlags(fs)
+	addr := fs.String("addr", ":9090", "Address to listen on")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	// サーバーは設定ファイルを監視し、[app] の変更を再起動せずに反映する
+	w, err := config.Watch(flags)
+	if err != nil {
+		log.Printf("設定読み込みエラー: %v\n", err)
+		return 2
+	}
+	cfg := w.Config()
+	boil.DebugMode = cfg.App.Debug
+
+	ctx, stop := database.SignalContext(context.Background())
+	defer stop()
+
+	db, err := database.Open(ctx, &cfg)
+	if err != nil {
+		log.Printf("データベース接続エラー: %v\n", err)
+		return 1
+	}
+	defer database.Shutdown(db, shutdownTimeout)
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Printf("待ち受けエラー: %v\n", err)
+		return 1
+	}
+
+	exec := database.NewExecutor(db, cfg.App.Timeouts)
+	w.OnChange(func(app config.App) {
+		exec.SetTimeouts(app.Timeouts)
+		log.Println("設定を再読み込みしました")
+	})
+
+	// 再読み込みした app.tenant_id と app.debug は RPC ごとの context に入れる
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			return handler(appContext(ctx, w.App()), req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, appStream{ss, appContext(ss.Context(), w.App())})
+		}),
+	)
+	grpcserver.Register(srv, exec)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	reflection.Register(srv)
+
+	// SIGINT/SIGTERM で受け付けを止め、処理中の RPC を待ってから終了する。待ちきれなければ打ち切る。
+	go func() {
+		<-ctx.Done()
+		done := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(shutdownTimeout):
+			srv.Stop()
+		}
+	}()
+
+	log.Printf("gRPC サーバーを起動しました: %s\n", lis.Addr())
+	if err := srv.Serve(lis); err != nil {
+		log.Printf("サーバーエラー: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// appStream は appContext で作った context を返す ServerStream
+type appStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s appStream) Context() context.Context { return s.ctx }
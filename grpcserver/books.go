@@ -0,0 +1,298 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"sqlboiler-project/database"
+	"sqlboiler-project/models"
+	catalogv1 "sqlboiler-project/proto/catalog/v1"
+)
+
+// StreamBooks が 1 回のクエリで読む件数の既定値
+const streamBatchSize = 500
+
+// invalidArgument は field の検証エラーを INVALID_ARGUMENT にする。詳細に BadRequest を付ける。
+func invalidArgument(field, msg string) error {
+	st := status.Newf(codes.InvalidArgument, "%s %s", field, msg)
+	if d, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: msg}},
+	}); err == nil {
+		st = d
+	}
+
+	return st.Err()
+}
+
+// validationError は models の検証エラーを INVALID_ARGUMENT にする
+func validationError(err error) error {
+	var ve *models.ValidationError
+	if errors.As(err, &ve) {
+		return invalidArgument(ve.Field, ve.Message)
+	}
+
+	return err
+}
+
+// validateText は必須の文字列を models.ValidateText で検証する
+func validateText(field, s string) (string, error) {
+	s, err := models.ValidateText(field, s)
+	return s, validationError(err)
+}
+
+// validateYear は出版年を models.ValidateYear で検証する。year が無ければ NULL にする。
+func validateYear(field string, year *int32) (null.Int, error) {
+	var v null.Int
+	if year != nil {
+		v = null.IntFrom(int(*year))
+	}
+
+	return v, validationError(models.ValidateYear(field, v))
+}
+
+// filterMods は BookFilter の指定された条件を models.BookWhere のクエリ修飾子にする
+func filterMods(f *catalogv1.BookFilter) []qm.QueryMod {
+	if f == nil {
+		return nil
+	}
+
+	var mods []qm.QueryMod
+	if len(f.IdIn) != 0 {
+		ids := make([]int, len(f.IdIn))
+		for i, id := range f.IdIn {
+			ids[i] = int(id)
+		}
+		mods = append(mods, models.BookWhere.ID.IN(ids))
+	}
+	if f.Title != nil {
+		mods = append(mods, models.BookWhere.Title.EQ(f.GetTitle()))
+	}
+	if f.TitleContains != nil {
+		mods = append(mods, models.BookWhere.Title.ILIKE(models.ContainsPattern(f.GetTitleContains())))
+	}
+	if f.AuthorName != nil {
+		mods = append(mods, models.BookWhere.AuthorName.EQ(f.GetAuthorName()))
+	}
+	if f.AuthorId != nil {
+		mods = append(mods, models.BookWhere.AuthorID.EQ(null.IntFrom(int(f.GetAuthorId()))))
+	}
+	if f.PublishedYear != nil {
+		mods = append(mods, models.BookWhere.PublishedYear.EQ(null.IntFrom(int(f.GetPublishedYear()))))
+	}
+	if f.PublishedYearGte != nil {
+		mods = append(mods, models.BookWhere.PublishedYear.GTE(null.IntFrom(int(f.GetPublishedYearGte()))))
+	}
+	if f.PublishedYearLte != nil {
+		mods = append(mods, models.BookWhere.PublishedYear.LTE(null.IntFrom(int(f.GetPublishedYearLte()))))
+	}
+	if f.PublishedYearIsNull != nil {
+		if f.GetPublishedYearIsNull() {
+			mods = append(mods, models.BookWhere.PublishedYear.IsNull())
+		} else {
+			mods = append(mods, models.BookWhere.PublishedYear.IsNotNull())
+		}
+	}
+
+	return mods
+}
+
+// GetBook は本を返す
+func (s *Server) GetBook(ctx context.Context, req *catalogv1.GetBookRequest) (*catalogv1.Book, error) {
+	b, err := models.FindBook(ctx, s.exec, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, "book")
+	}
+
+	return toBook(b), nil
+}
+
+// ListBooks は filter に合う本を id 順に 1 ページ返す
+func (s *Server) ListBooks(ctx context.Context, req *catalogv1.ListBooksRequest) (*catalogv1.ListBooksResponse, error) {
+	p, err := newPage(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	mods := append(filterMods(req.GetFilter()), p.mods(models.BookTableColumns.ID)...)
+	books, err := models.Books(mods...).All(ctx, s.exec)
+	if err != nil {
+		return nil, toStatus(err, "book")
+	}
+	books, next := trimPage(books, p.size, func(b *models.Book) int { return b.ID })
+
+	return &catalogv1.ListBooksResponse{Books: toBooks(books), NextPageToken: next}, nil
+}
+
+// StreamBooks は filter に合う本を id 順にすべて流す。
+// 全件を一度に読まないよう、batchSize 件ずつ直前の id の次から読む。
+func (s *Server) StreamBooks(req *catalogv1.StreamBooksRequest, stream catalogv1.CatalogService_StreamBooksServer) error {
+	ctx := stream.Context()
+	filter := filterMods(req.GetFilter())
+
+	p := page{size: s.batchSize}
+	for {
+		mods := append(append([]qm.QueryMod(nil), filter...), p.mods(models.BookTableColumns.ID)...)
+		books, err := models.Books(mods...).All(ctx, s.exec)
+		if err != nil {
+			return toStatus(err, "book")
+		}
+
+		// 次の回を読むか判断するため 1 件多く読んでいる
+		more := len(books) > p.size
+		if more {
+			books = books[:p.size]
+		}
+		for _, b := range books {
+			if err := stream.Send(toBook(b)); err != nil {
+				return err
+			}
+		}
+		if !more {
+			return nil
+		}
+
+		last := books[len(books)-1].ID
+		p.after = &last
+	}
+}
+
+// CreateBook は本を検証して作成する
+func (s *Server) CreateBook(ctx context.Context, req *catalogv1.CreateBookRequest) (*catalogv1.Book, error) {
+	b := &models.Book{}
+
+	var err error
+	if b.Title, err = validateText("title", req.GetTitle()); err != nil {
+		return nil, err
+	}
+	authorName, err := validateText("author_name", req.GetAuthorName())
+	if err != nil {
+		return nil, err
+	}
+	if b.PublishedYear, err = validateYear("published_year", req.PublishedYear); err != nil {
+		return nil, err
+	}
+
+	// 著者を作ってから本の INSERT に失敗したときに著者だけが残らないようにする
+	err = database.InTx(ctx, s.exec, func(exec boil.ContextExecutor) error {
+		if err := b.AssignAuthor(ctx, exec, authorName); err != nil {
+			return toStatus(err, "author")
+		}
+		return toStatus(b.Insert(ctx, exec, boil.Infer()), "book")
+	})
+	if err != nil {
+		return nil, toStatus(err, "book")
+	}
+
+	return toBook(b), nil
+}
+
+// UpdateBook は update_mask で指定したフィールドだけを検証して更新する。update_mask が空ならすべて更新する。
+func (s *Server) UpdateBook(ctx context.Context, req *catalogv1.UpdateBookRequest) (*catalogv1.Book, error) {
+	in := req.GetBook()
+	if in == nil {
+		return nil, invalidArgument("book", "is required")
+	}
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = []string{"title", "author_name", "published_year"}
+	}
+
+	// 検証は読み込む前に済ませる
+	var cols []string
+	var title, authorName string
+	var year null.Int
+	var err error
+	for _, path := range paths {
+		switch path {
+		case "title":
+			if title, err = validateText("book.title", in.GetTitle()); err != nil {
+				return nil, err
+			}
+			cols = append(cols, models.BookColumns.Title)
+		case "author_name":
+			if authorName, err = validateText("book.author_name", in.GetAuthorName()); err != nil {
+				return nil, err
+			}
+			cols = append(cols, models.BookColumns.AuthorName, models.BookColumns.AuthorID)
+		case "published_year":
+			if year, err = validateYear("book.published_year", in.PublishedYear); err != nil {
+				return nil, err
+			}
+			cols = append(cols, models.BookColumns.PublishedYear)
+		default:
+			return nil, invalidArgument("update_mask", fmt.Sprintf("has unknown path %q", path))
+		}
+	}
+
+	b, err := models.FindBook(ctx, s.exec, int(in.GetId()))
+	if err != nil {
+		return nil, toStatus(err, "book")
+	}
+
+	err = database.InTx(ctx, s.exec, func(exec boil.ContextExecutor) error {
+		for _, col := range cols {
+			switch col {
+			case models.BookColumns.Title:
+				b.Title = title
+			case models.BookColumns.AuthorName:
+				if err := b.AssignAuthor(ctx, exec, authorName); err != nil {
+					return toStatus(err, "author")
+				}
+			case models.BookColumns.PublishedYear:
+				b.PublishedYear = year
+			}
+		}
+
+		_, err := b.Update(ctx, exec, boil.Whitelist(cols...))
+		return toStatus(err, "book")
+	})
+	if err != nil {
+		return nil, toStatus(err, "book")
+	}
+
+	return toBook(b), nil
+}
+
+// DeleteBook は本を削除する。1 行ずつのフックを通すため、読み込んでから削除する。
+func (s *Server) DeleteBook(ctx context.Context, req *catalogv1.DeleteBookRequest) (*emptypb.Empty, error) {
+	b, err := models.FindBook(ctx, s.exec, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, "book")
+	}
+
+	if _, err := b.Delete(ctx, s.exec); err != nil {
+		return nil, toStatus(err, "book")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// SearchBooks はタイトルか著者名に keyword を含む本をタイトル順に返す（models.SearchBooks）
+func (s *Server) SearchBooks(ctx context.Context, req *catalogv1.SearchBooksRequest) (*catalogv1.SearchBooksResponse, error) {
+	keyword := strings.TrimSpace(req.GetKeyword())
+	if keyword == "" {
+		return nil, invalidArgument("keyword", "must not be empty")
+	}
+	limit, err := pageSize("limit", req.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+
+	books, err := models.SearchBooks(keyword, limit).All(ctx, s.exec)
+	if err != nil {
+		return nil, toStatus(err, "book")
+	}
+
+	return &catalogv1.SearchBooksResponse{Books: toBooks(books)}, nil
+}
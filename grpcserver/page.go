@@ -0,0 +1,97 @@
+package grpcserver
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// page_size / limit が 0 のときの件数
+	defaultPageSize = 20
+	// page_size / limit の上限。超えた分は切り詰める。
+	maxPageSize = 100
+)
+
+// tokenPrefix はページトークンの中身の接頭辞。トークンは id 順のページングの位置（直前の行の id）を持つ。
+const tokenPrefix = "id:"
+
+// encodePageToken は id の行の直後から読むページトークンを返す
+func encodePageToken(id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(tokenPrefix + strconv.Itoa(id)))
+}
+
+// decodePageToken はページトークンが指す id を返す
+func decodePageToken(token string) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil && strings.HasPrefix(string(b), tokenPrefix) {
+		if id, err := strconv.Atoi(strings.TrimPrefix(string(b), tokenPrefix)); err == nil {
+			return id, nil
+		}
+	}
+
+	return 0, status.Errorf(codes.InvalidArgument, "invalid page_token %q", token)
+}
+
+// pageSize は要求された件数を既定値と上限に合わせる
+func pageSize(field string, size int32) (int, error) {
+	switch {
+	case size < 0:
+		return 0, invalidArgument(field, "must not be negative")
+	case size == 0:
+		return defaultPageSize, nil
+	case size > maxPageSize:
+		return maxPageSize, nil
+	}
+
+	return int(size), nil
+}
+
+// page は id 順のページングの 1 ページ
+type page struct {
+	size  int
+	after *int
+}
+
+func newPage(size int32, token string) (page, error) {
+	n, err := pageSize("page_size", size)
+	if err != nil {
+		return page{}, err
+	}
+
+	p := page{size: n}
+	if token != "" {
+		after, err := decodePageToken(token)
+		if err != nil {
+			return page{}, err
+		}
+		p.after = &after
+	}
+
+	return p, nil
+}
+
+// mods は idColumn 順にページの位置から読むクエリ修飾子を返す。次のページがあるかを調べるため 1 件多く読む。
+func (p page) mods(idColumn string) []qm.QueryMod {
+	mods := []qm.QueryMod{qm.OrderBy(idColumn + " ASC"), qm.Limit(p.size + 1)}
+	if p.after != nil {
+		mods = append(mods, qm.Where(fmt.Sprintf("%s > ?", idColumn), *p.after))
+	}
+
+	return mods
+}
+
+// trimPage は mods で読んだ rows（最大 size+1 件）をページの分にし、次のページがあればそのトークンを返す
+func trimPage[T any](rows []T, size int, id func(T) int) ([]T, string) {
+	if len(rows) <= size {
+		return rows, ""
+	}
+
+	rows = rows[:size]
+	return rows, encodePageToken(id(rows[size-1]))
+}
@@ -0,0 +1,168 @@
+// Package grpcserver は生成されたモデルの上に gRPC の CatalogService（proto/catalog/v1）を提供する。
+// 本の読み書きと検索、ユーザーと映画の読み取りを扱う。
+package grpcserver
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"sqlboiler-project/models"
+	catalogv1 "sqlboiler-project/proto/catalog/v1"
+)
+
+// Server は CatalogService の実装
+type Server struct {
+	catalogv1.UnimplementedCatalogServiceServer
+
+	exec boil.ContextExecutor
+	// StreamBooks が 1 回のクエリで読む件数
+	batchSize int
+}
+
+// New は exec でクエリを実行する Server を返す
+func New(exec boil.ContextExecutor) *Server {
+	return &Server{exec: exec, batchSize: streamBatchSize}
+}
+
+// Register は exec でクエリを実行する CatalogService を s に登録する
+func Register(s grpc.ServiceRegistrar, exec boil.ContextExecutor) {
+	catalogv1.RegisterCatalogServiceServer(s, New(exec))
+}
+
+// toStatus はモデルのエラーを gRPC のステータスにする。what は見つからなかったときのメッセージに使う。
+func toStatus(err error, what string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Errorf(codes.NotFound, "%s not found", what)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+func nullInt32(v null.Int) *int32 {
+	if !v.Valid {
+		return nil
+	}
+	i := int32(v.Int)
+	return &i
+}
+
+func nullTimestamp(v null.Time) *timestamppb.Timestamp {
+	if !v.Valid {
+		return nil
+	}
+	return timestamppb.New(v.Time)
+}
+
+func toBook(b *models.Book) *catalogv1.Book {
+	return &catalogv1.Book{
+		Id:            int64(b.ID),
+		Title:         b.Title,
+		AuthorName:    b.AuthorName,
+		PublishedYear: nullInt32(b.PublishedYear),
+	}
+}
+
+func toBooks(books models.BookSlice) []*catalogv1.Book {
+	ret := make([]*catalogv1.Book, len(books))
+	for i, b := range books {
+		ret[i] = toBook(b)
+	}
+
+	return ret
+}
+
+func toUser(u *models.User) *catalogv1.User {
+	return &catalogv1.User{
+		Id:         int64(u.ID),
+		Name:       u.Name,
+		CreateTime: nullTimestamp(u.CreatedAt),
+	}
+}
+
+func toMovie(m *models.Movie) *catalogv1.Movie {
+	return &catalogv1.Movie{
+		Id:          int64(m.ID),
+		Title:       m.Title,
+		ReleaseYear: nullInt32(m.ReleaseYear),
+	}
+}
+
+// GetUser はユーザーを返す
+func (s *Server) GetUser(ctx context.Context, req *catalogv1.GetUserRequest) (*catalogv1.User, error) {
+	u, err := models.FindUser(ctx, s.exec, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, "user")
+	}
+
+	return toUser(u), nil
+}
+
+// ListUsers はユーザーを id 順に 1 ページ返す
+func (s *Server) ListUsers(ctx context.Context, req *catalogv1.ListUsersRequest) (*catalogv1.ListUsersResponse, error) {
+	p, err := newPage(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := models.Users(p.mods(models.UserTableColumns.ID)...).All(ctx, s.exec)
+	if err != nil {
+		return nil, toStatus(err, "user")
+	}
+	users, next := trimPage(users, p.size, func(u *models.User) int { return u.ID })
+
+	resp := &catalogv1.ListUsersResponse{NextPageToken: next}
+	for _, u := range users {
+		resp.Users = append(resp.Users, toUser(u))
+	}
+
+	return resp, nil
+}
+
+// GetMovie は映画を返す
+func (s *Server) GetMovie(ctx context.Context, req *catalogv1.GetMovieRequest) (*catalogv1.Movie, error) {
+	m, err := models.FindMovie(ctx, s.exec, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, "movie")
+	}
+
+	return toMovie(m), nil
+}
+
+// ListMovies は映画を id 順に 1 ページ返す
+func (s *Server) ListMovies(ctx context.Context, req *catalogv1.ListMoviesRequest) (*catalogv1.ListMoviesResponse, error) {
+	p, err := newPage(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	movies, err := models.Movies(p.mods(models.MovieTableColumns.ID)...).All(ctx, s.exec)
+	if err != nil {
+		return nil, toStatus(err, "movie")
+	}
+	movies, next := trimPage(movies, p.size, func(m *models.Movie) int { return m.ID })
+
+	resp := &catalogv1.ListMoviesResponse{NextPageToken: next}
+	for _, m := range movies {
+		resp.Movies = append(resp.Movies, toMovie(m))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,299 @@
+package grpcserver
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"sqlboiler-project/internal/testdb"
+	"sqlboiler-project/models"
+	catalogv1 "sqlboiler-project/proto/catalog/v1"
+)
+
+var bookCols = []string{"id", "title", "author", "published_year", "created_at", "tenant_id", "author_id"}
+
+// dial は 5 冊の本を返す Fake を使う srv を起動し、つないだクライアントを返す
+func dial(t *testing.T, srv *Server) (catalogv1.CatalogServiceClient, *testdb.Fake) {
+	t.Helper()
+
+	var rows [][]driver.Value
+	for i := int64(1); i <= 5; i++ {
+		rows = append(rows, []driver.Value{i, "Book " + strconv.FormatInt(i, 10), "Lem", 1960 + i, nil, int64(0), nil})
+	}
+	books := testdb.Table{Cols: bookCols, Rows: rows}
+	db, f := testdb.OpenFake(t, map[string]testdb.Table{"books": books, "books_with_author": books})
+	srv.exec = db
+
+	return connect(t, srv), f
+}
+
+// connect は srv を bufconn で起動し、つないだクライアントを返す
+func connect(t *testing.T, srv *Server) catalogv1.CatalogServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	catalogv1.RegisterCatalogServiceServer(gs, srv)
+	go func() { _ = gs.Serve(lis) }()
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return catalogv1.NewCatalogServiceClient(conn)
+}
+
+func TestGetBook(t *testing.T) {
+	t.Parallel()
+
+	client, _ := dial(t, New(nil))
+	ctx := context.Background()
+
+	b, err := client.GetBook(ctx, &catalogv1.GetBookRequest{Id: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &catalogv1.Book{Id: 2, Title: "Book 2", AuthorName: "Lem", PublishedYear: proto.Int32(1962)}
+	if !proto.Equal(b, want) {
+		t.Errorf("got %v, want %v", b, want)
+	}
+
+	if _, err := client.GetBook(ctx, &catalogv1.GetBookRequest{Id: 42}); status.Code(err) != codes.NotFound {
+		t.Errorf("missing book: %v", err)
+	}
+	if _, err := client.DeleteBook(ctx, &catalogv1.DeleteBookRequest{Id: 42}); status.Code(err) != codes.NotFound {
+		t.Errorf("deleting a missing book: %v", err)
+	}
+}
+
+func TestListBooksPages(t *testing.T) {
+	t.Parallel()
+
+	client, f := dial(t, New(nil))
+	ctx := context.Background()
+
+	req := &catalogv1.ListBooksRequest{
+		Filter:   &catalogv1.BookFilter{TitleContains: proto.String("100%"), PublishedYearIsNull: proto.Bool(false)},
+		PageSize: 2,
+	}
+	var ids []int64
+	for {
+		resp, err := client.ListBooks(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, b := range resp.Books {
+			ids = append(ids, b.Id)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	if len(ids) != 5 || ids[0] != 1 || ids[4] != 5 {
+		t.Errorf("ids = %v", ids)
+	}
+
+	queries := f.Take()
+	if len(queries) != 3 {
+		t.Fatalf("want 3 pages, got %v", queries)
+	}
+	for _, want := range []string{`"books"."title" ILIKE $1`, `"books"."published_year" is not null`, `books.id > $2`, `LIMIT 3`} {
+		if !strings.Contains(queries[1], want) {
+			t.Errorf("missing %q in %s", want, queries[1])
+		}
+	}
+
+	if _, err := client.ListBooks(ctx, &catalogv1.ListBooksRequest{PageToken: "!!"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid page token: %v", err)
+	}
+}
+
+func TestStreamBooks(t *testing.T) {
+	t.Parallel()
+
+	srv := New(nil)
+	srv.batchSize = 2
+	client, f := dial(t, srv)
+
+	stream, err := client.StreamBooks(context.Background(), &catalogv1.StreamBooksRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int64
+	for {
+		b, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, b.Id)
+	}
+	if len(ids) != 5 || ids[0] != 1 || ids[4] != 5 {
+		t.Errorf("ids = %v", ids)
+	}
+
+	// 2 件ずつ読むので 3 回
+	if q := f.Take(); len(q) != 3 {
+		t.Errorf("want 3 batches, got %v", q)
+	}
+}
+
+func TestCreateBookValidation(t *testing.T) {
+	t.Parallel()
+
+	client, f := dial(t, New(nil))
+	ctx := context.Background()
+
+	_, err := client.CreateBook(ctx, &catalogv1.CreateBookRequest{Title: "Solaris", AuthorName: "Lem", PublishedYear: proto.Int32(0)})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("want INVALID_ARGUMENT, got %v", err)
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf("details = %v", st.Details())
+	}
+	br, ok := st.Details()[0].(*errdetails.BadRequest)
+	if !ok || br.FieldViolations[0].Field != "published_year" {
+		t.Errorf("details = %v", st.Details())
+	}
+
+	_, err = client.UpdateBook(ctx, &catalogv1.UpdateBookRequest{
+		Book:       &catalogv1.Book{Id: 1},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"id"}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unknown update_mask path: %v", err)
+	}
+
+	if q := f.Take(); len(q) != 0 {
+		t.Errorf("invalid input must not reach the database: %v", q)
+	}
+}
+
+func TestUpdateBookMask(t *testing.T) {
+	t.Parallel()
+
+	client, f := dial(t, New(nil))
+
+	b, err := client.UpdateBook(context.Background(), &catalogv1.UpdateBookRequest{
+		Book:       &catalogv1.Book{Id: 3, Title: " Fiasco ", AuthorName: "ignored"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title", "published_year"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &catalogv1.Book{Id: 3, Title: "Fiasco", AuthorName: "Lem"}
+	if !proto.Equal(b, want) {
+		t.Errorf("got %v, want %v", b, want)
+	}
+
+	queries := f.Take()
+	if len(queries) != 2 {
+		t.Fatalf("want find and update, got %v", queries)
+	}
+	if !strings.Contains(queries[1], `SET "title"=$1,"published_year"=$2`) {
+		t.Errorf("only the masked columns should be updated: %s", queries[1])
+	}
+}
+
+func TestSearchBooks(t *testing.T) {
+	t.Parallel()
+
+	client, f := dial(t, New(nil))
+
+	resp, err := client.SearchBooks(context.Background(), &catalogv1.SearchBooksRequest{Keyword: "Book", Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Books) != 2 {
+		t.Errorf("books = %v", resp.Books)
+	}
+
+	q := f.Take()
+	if len(q) != 1 || !strings.Contains(q[0], `FROM "books_with_author" as "books"`) || !strings.Contains(q[0], "LIMIT 2") {
+		t.Errorf("queries = %v", q)
+	}
+}
+
+func TestBooksWithDatabase(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.Open(t)
+	client := connect(t, New(db))
+	ctx := context.Background()
+
+	created, err := client.CreateBook(ctx, &catalogv1.CreateBookRequest{Title: "Solaris", AuthorName: "Stanisław Lem", PublishedYear: proto.Int32(1961)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.CreateBook(ctx, &catalogv1.CreateBookRequest{Title: "100% Lem", AuthorName: " stanisław  lem"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := client.GetBook(ctx, &catalogv1.GetBookRequest{Id: created.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, created) {
+		t.Errorf("got %v, want %v", got, created)
+	}
+
+	// 表記ゆれのある著者名は同じ著者にまとめる
+	if n, err := models.Authors().Count(ctx, db); err != nil || n != 1 {
+		t.Errorf("authors = %d, %v", n, err)
+	}
+
+	// % は文字どおりに扱う
+	resp, err := client.ListBooks(ctx, &catalogv1.ListBooksRequest{Filter: &catalogv1.BookFilter{TitleContains: proto.String("100%")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Books) != 1 || resp.Books[0].Title != "100% Lem" {
+		t.Errorf("list = %v", resp.Books)
+	}
+
+	in := &catalogv1.Book{Id: created.Id, AuthorName: "Ursula K. Le Guin"}
+	if _, err := client.UpdateBook(ctx, &catalogv1.UpdateBookRequest{Book: in, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"author_name"}}}); err != nil {
+		t.Fatal(err)
+	}
+	b, err := models.FindBook(ctx, db, int(created.Id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	author, err := b.Author().One(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Title != "Solaris" || author.Name != "Ursula K. Le Guin" {
+		t.Errorf("title %q, author %q", b.Title, author.Name)
+	}
+
+	if _, err := client.DeleteBook(ctx, &catalogv1.DeleteBookRequest{Id: created.Id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetBook(ctx, &catalogv1.GetBookRequest{Id: created.Id}); status.Code(err) != codes.NotFound {
+		t.Errorf("deleted book: %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+package testdb
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/friendsofgo/errors"
+	"github.com/lib/pq"
+)
+
+// templateVersion はテンプレート DB の作り方の版。マイグレーション以外の手順を変えたら上げて、古いテンプレートを使わないようにする。
+const templateVersion = 2
+
+// Cloner は db/migrations を流したテンプレート DB を用意し、そこからテスト用 DB を複製する。
+// テンプレート名にはマイグレーションのハッシュを含めるので、マイグレーションが変わらなければ次回以降も再利用される。
+type Cloner struct {
+	// Admin は DB の作成・削除に使う接続（postgres DB など、複製する DB 以外につなぐ）
+	Admin *sql.DB
+	// ConnStr は DB 名から接続文字列を作る
+	ConnStr func(dbName string) string
+	// Prefix はテンプレート DB の名前の接頭辞
+	Prefix string
+
+	template string
+
+	mu  sync.Mutex
+	seq int64
+}
+
+// EnsureTemplate はテンプレート DB が無ければ作る
+func (c *Cloner) EnsureTemplate() error {
+	dir := MigrationsDir()
+	sum, err := MigrationsHash(dir)
+	if err != nil {
+		return err
+	}
+	c.template = fmt.Sprintf("%s_tmpl_%d_%s", c.Prefix, templateVersion, sum[:12])
+
+	// 複数のテストバイナリが同時にテンプレートを作らないよう、サーバー全体で排他する
+	// ロックは接続に結び付くので、解放まで同じ接続を使う
+	ctx := context.Background()
+	lock, err := c.Admin.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer lock.Close()
+	if _, err = lock.ExecContext(ctx, "select pg_advisory_lock(hashtext($1))", c.Prefix); err != nil {
+		return errors.Wrap(err, "failed to lock template database")
+	}
+	defer func() { _, _ = lock.ExecContext(ctx, "select pg_advisory_unlock(hashtext($1))", c.Prefix) }()
+
+	var exists bool
+	if err = c.Admin.QueryRow("select exists(select 1 from pg_database where datname = $1)", c.template).Scan(&exists); err != nil {
+		return errors.Wrap(err, "failed to look up template database")
+	}
+	if exists {
+		return nil
+	}
+
+	c.dropStaleTemplates()
+
+	// 途中で失敗したテンプレートを残さないよう、別名で作ってから付け替える
+	building := c.template + "_building"
+	if err = c.Drop(building); err != nil {
+		return err
+	}
+	if _, err = c.Admin.Exec("CREATE DATABASE " + pq.QuoteIdentifier(building)); err != nil {
+		return errors.Wrap(err, "failed to create template database")
+	}
+
+	db, err := sql.Open("postgres", c.ConnStr(building))
+	if err != nil {
+		return err
+	}
+	err = ApplyMigrations(db, dir)
+	if closeErr := db.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = c.Drop(building)
+		return err
+	}
+
+	_, err = c.Admin.Exec(fmt.Sprintf("ALTER DATABASE %s RENAME TO %s", pq.QuoteIdentifier(building), pq.QuoteIdentifier(c.template)))
+	return errors.Wrap(err, "failed to rename template database")
+}
+
+// dropStaleTemplates は古いマイグレーションから作られたテンプレートを消す
+func (c *Cloner) dropStaleTemplates() {
+	rows, err := c.Admin.Query("select datname from pg_database where datname like $1", c.Prefix+"\\_tmpl\\_%")
+	if err != nil {
+		return
+	}
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if rows.Scan(&name) == nil {
+			names = append(names, name)
+		}
+	}
+	_ = rows.Close()
+
+	for _, name := range names {
+		_ = c.Drop(name)
+	}
+}
+
+// Create はテンプレートから name の DB を作る。同じ名前の DB があれば作り直す。
+func (c *Cloner) Create(name string) error {
+	// 同じテンプレートからの CREATE DATABASE は並行に走らせない
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.Drop(name); err != nil {
+		return err
+	}
+
+	_, err := c.Admin.Exec(fmt.Sprintf("CREATE DATABASE %s TEMPLATE %s", pq.QuoteIdentifier(name), pq.QuoteIdentifier(c.template)))
+	return errors.Wrapf(err, "failed to clone %s", c.template)
+}
+
+// Drop は name の DB を接続ごと削除する
+func (c *Cloner) Drop(name string) error {
+	_, err := c.Admin.Exec("DROP DATABASE IF EXISTS " + pq.QuoteIdentifier(name) + " WITH (FORCE)")
+	return errors.Wrapf(err, "failed to drop %s", name)
+}
+
+// Isolated は t 専用の DB を base に連番を付けた名前で複製して返す。テスト終了時に削除する。
+func (c *Cloner) Isolated(t testing.TB, base string) *sql.DB {
+	t.Helper()
+
+	name := fmt.Sprintf("%s_%d", base, atomic.AddInt64(&c.seq, 1))
+	if err := c.Create(name); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("postgres", c.ConnStr(name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = db.Close()
+		if err := c.Drop(name); err != nil {
+			t.Error(err)
+		}
+	})
+
+	return db
+}
+
+// rootDir はリポジトリのルート
+func rootDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..")
+}
+
+// MigrationsDir はリポジトリの db/migrations を指す
+func MigrationsDir() string {
+	return filepath.Join(rootDir(), "db", "migrations")
+}
+
+func migrationFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.Errorf("no migrations found in %s", dir)
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// MigrationsHash はマイグレーションの内容から決まるハッシュを返す
+func MigrationsHash(dir string) (string, error) {
+	files, err := migrationFiles(dir)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00", filepath.Base(f), b)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ApplyMigrations は dir の *.up.sql をファイル名順に流す
+func ApplyMigrations(db *sql.DB, dir string) error {
+	files, err := migrationFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		if _, err = db.Exec(string(b)); err != nil {
+			return errors.Wrapf(err, "failed to apply migration %s", filepath.Base(f))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package testdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// Table は Fake が返すテーブルの列と行。行の先頭の列は id（int64）とする。
+type Table struct {
+	Cols []string
+	Rows [][]driver.Value
+}
+
+// Fake は SELECT の FROM のテーブルの行を返し、実行したクエリを記録するドライバ。
+// 行は "id"=$n・id > $n の条件と LIMIT で絞る。それ以外の条件は見ないので、期待する行だけを Tables に入れておく。
+// INSERT・UPDATE・DELETE は記録するだけで、引数の数を影響した行数として返す（"id" IN ($1,$2) の削除なら 2 行）。
+// トランザクションは開始できるが、コミットもロールバックも何もしない（記録もしない）。
+type Fake struct {
+	Tables map[string]Table
+
+	mu      sync.Mutex
+	queries []string
+}
+
+var (
+	rgxFrom    = regexp.MustCompile(`(?i)FROM "(\w+)"`)
+	rgxIDEq    = regexp.MustCompile(`"id"=\$(\d+)`)
+	rgxIDAfter = regexp.MustCompile(`id > \$(\d+)`)
+	rgxLimit   = regexp.MustCompile(`LIMIT (\d+)`)
+)
+
+// OpenFake は tables を返す Fake を開く。テスト終了時に閉じる。
+func OpenFake(t testing.TB, tables map[string]Table) (*sql.DB, *Fake) {
+	t.Helper()
+
+	f := &Fake{Tables: tables}
+	db := sql.OpenDB(f)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db, f
+}
+
+func (f *Fake) Connect(context.Context) (driver.Conn, error) { return &fakeConn{f: f}, nil }
+func (f *Fake) Driver() driver.Driver                        { return nil }
+
+// Take は前回の Take 以降に実行したクエリを返す
+func (f *Fake) Take() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ret := f.queries
+	f.queries = nil
+	return ret
+}
+
+type fakeConn struct{ f *Fake }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return c, nil }
+func (c *fakeConn) Commit() error                             { return nil }
+func (c *fakeConn) Rollback() error                           { return nil }
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.f.mu.Lock()
+	c.f.queries = append(c.f.queries, query)
+	c.f.mu.Unlock()
+
+	return driver.RowsAffected(len(args)), nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	c.f.queries = append(c.f.queries, query)
+
+	var t Table
+	if m := rgxFrom.FindStringSubmatch(query); m != nil {
+		t = c.f.Tables[m[1]]
+	}
+
+	// $n の引数
+	arg := func(m []string) int64 {
+		n, _ := strconv.Atoi(m[1])
+		return args[n-1].Value.(int64)
+	}
+
+	var rows [][]driver.Value
+	for _, r := range t.Rows {
+		id := r[0].(int64)
+		if m := rgxIDEq.FindStringSubmatch(query); m != nil && id != arg(m) {
+			continue
+		}
+		if m := rgxIDAfter.FindStringSubmatch(query); m != nil && id <= arg(m) {
+			continue
+		}
+		rows = append(rows, r)
+	}
+	if m := rgxLimit.FindStringSubmatch(query); m != nil {
+		if n, _ := strconv.Atoi(m[1]); n < len(rows) {
+			rows = rows[:n]
+		}
+	}
+
+	return &fakeRows{cols: t.Cols, vals: rows}, nil
+}
+
+type fakeRows struct {
+	cols []string
+	vals [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.cols }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.vals) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.vals[0])
+	r.vals = r.vals[1:]
+	return nil
+}
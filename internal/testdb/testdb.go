@@ -0,0 +1,93 @@
+// Package testdb はテスト用の DB を用意する。
+//
+// Open は db/migrations から作ったテンプレートを複製して、テストごとに空の PostgreSQL の DB を開く。
+// 接続先は models のテストと同じく sqlboiler.toml の [psql]（環境変数 PSQL_HOST などで上書きできる）で、
+// サーバーにつながらなければテストを Skip する。
+//
+// Fake は決まった行を返して実行したクエリを記録する database/sql のドライバで、
+// PostgreSQL を使わずに組み立てた SQL と結果の変換を確かめるのに使う。
+package testdb
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/friendsofgo/errors"
+	"github.com/spf13/viper"
+	"github.com/volatiletech/sqlboiler/v4/drivers/sqlboiler-psql/driver"
+)
+
+var (
+	setupOnce sync.Once
+	cloner    *Cloner
+	setupErr  error
+	skipErr   error
+)
+
+// Open は t 専用の DB をテンプレートから複製して開く。テスト終了時に削除する。
+// -short のときや PostgreSQL につながらないときは t を Skip する。
+func Open(t testing.TB) *sql.DB {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("testdb: skipping database test in short mode")
+	}
+
+	setupOnce.Do(setup)
+	if skipErr != nil {
+		t.Skipf("testdb: PostgreSQL is not available: %v", skipErr)
+	}
+	if setupErr != nil {
+		t.Fatal(setupErr)
+	}
+
+	// go test はパッケージごとに別のプロセスで並行に走るので、DB 名にプロセス ID を含める
+	return cloner.Isolated(t, fmt.Sprintf("%s_test_%d", cloner.Prefix, os.Getpid()))
+}
+
+// setup は sqlboiler.toml の [psql] を読んでテンプレート DB を用意する
+func setup() {
+	v := viper.New()
+	v.SetConfigName("sqlboiler")
+	v.SetConfigType("toml")
+	v.AddConfigPath(rootDir())
+	v.SetDefault("psql.port", 5432)
+	v.SetDefault("psql.sslmode", "require")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	if err := v.ReadInConfig(); err != nil {
+		setupErr = errors.Wrap(err, "testdb: failed to read sqlboiler.toml")
+		return
+	}
+
+	user, pass := v.GetString("psql.user"), v.GetString("psql.pass")
+	host, port := v.GetString("psql.host"), v.GetInt("psql.port")
+	sslmode := v.GetString("psql.sslmode")
+	connStr := func(dbName string) string {
+		return driver.PSQLBuildQueryString(user, pass, dbName, host, port, sslmode)
+	}
+
+	admin, err := sql.Open("postgres", connStr("postgres"))
+	if err != nil {
+		setupErr = err
+		return
+	}
+	if err = admin.Ping(); err != nil {
+		_ = admin.Close()
+		skipErr = err
+		return
+	}
+
+	c := &Cloner{Admin: admin, ConnStr: connStr, Prefix: v.GetString("psql.dbname")}
+	if err = c.EnsureTemplate(); err != nil {
+		_ = admin.Close()
+		setupErr = err
+		return
+	}
+
+	cloner = c
+}
@@ -2,47 +2,99 @@ package main
 
 import (
 	"context"
-	"database/sql"
+	"flag"
 	"fmt"
 	"log"
+	"os"
+	"sqlboiler-project/cli"
+	"sqlboiler-project/config"
+	"sqlboiler-project/database"
+	"sqlboiler-project/dto"
 	"sqlboiler-project/models"
+	"sqlboiler-project/tenant"
+	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
+// 終了時に実行中のクエリを待つ時間
+const shutdownTimeout = 10 * time.Second
+
 // searchBooks関数はmain関数の外で定義
-func searchBooks(ctx context.Context, db *sql.DB, keyword string) ([]*models.Book, error) {
-	return models.Books(
-		qm.Where("title LIKE ? OR author LIKE ?",
-			"%"+keyword+"%",
-			"%"+keyword+"%",
-		),
-		qm.OrderBy("title ASC"),
-	).All(ctx, db)
+// 検索条件は gRPC サーバーと共通の models.SearchBooks にある
+func searchBooks(ctx context.Context, exec boil.ContextExecutor, keyword string, limit int) ([]*models.Book, error) {
+	return models.SearchBooks(keyword, limit).All(ctx, exec)
 }
 
 func main() {
-	// データベース接続
-	db, err := sql.Open("postgres", "host=localhost port=5432 user=user password=password dbname=sqlboiler_db sslmode=disable")
+	// サブコマンド
+	// go run . seed [-size small|medium|large] [-seed N] [-reset]
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		os.Exit(runSeed(os.Args[2:]))
+	}
+	// go run . graphql [-addr :8080]
+	if len(os.Args) > 1 && os.Args[1] == "graphql" {
+		os.Exit(runGraphQL(os.Args[2:]))
+	}
+	// go run . grpc [-addr :9090]
+	if len(os.Args) > 1 && os.Args[1] == "grpc" {
+		os.Exit(runGRPC(os.Args[2:]))
+	}
+	// go run . schema check [-schema public] [-models models]
+	if len(os.Args) > 1 && os.Args[1] == "schema" {
+		os.Exit(runSchema(os.Args[2:]))
+	}
+	// go run . books list [-where 'published_year>=2000'] [-o table|json|csv]
+	// go run . favorites add 1 2 など。一覧は go run . books（サブコマンドなし）で表示する。
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(runCatalog(os.Args[1:]))
+	}
+
+	// 設定の読み込み（sqlboiler.toml < 環境変数 < フラグ）
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	flags := config.RegisterFlags(fs)
+	_ = fs.Parse(os.Args[1:])
+
+	cfg, err := config.Load(flags)
+	if err != nil {
+		log.Printf("設定読み込みエラー: %v\n", err)
+		os.Exit(2)
+	}
+	boil.DebugMode = cfg.App.Debug
+
+	// SIGINT/SIGTERM で ctx がキャンセルされ、実行中のクエリも中断される
+	ctx, stop := database.SignalContext(context.Background())
+	defer stop()
+	// RLS で app.tenant_id のテナントの行だけを扱う
+	ctx = tenant.WithTenant(ctx, cfg.App.TenantID)
+
+	// データベース接続（起動直後でまだ応答しない場合は psql.ready_timeout まで待つ）
+	db, err := database.Open(ctx, cfg)
 	if err != nil {
 		log.Printf("データベース接続エラー: %v\n", err)
 		return
 	}
-	defer db.Close()
+	defer func() {
+		if err := database.Shutdown(db, shutdownTimeout); err != nil {
+			log.Printf("データベース切断エラー: %v\n", err)
+		}
+	}()
 
-	ctx := context.Background()
+	// 文ごとに種類に応じたタイムアウト（[app.timeouts]）を付ける
+	exec := database.NewExecutor(db, cfg.App.Timeouts)
 
 	// エラーチェックを追加
-	books, err := models.Books().All(ctx, db)
+	books, err := models.Books().All(ctx, exec)
 	if err != nil {
 		log.Printf("本の取得エラー: %v\n", err)
 		return
 	}
 
+	// boil.SetDB で設定したグローバルな DB を使う
 	boil.SetDB(db)
-	booksG, err := models.Books().AllG(ctx)
+	booksG, err := models.Books().All(ctx, boil.GetContextDB())
 	if err != nil {
 		log.Printf("本の取得エラー: %v\n", err)
 		return
@@ -50,8 +102,8 @@ func main() {
 
 	booksWithCondition, err := models.Books(
 		qm.Where("title LIKE ?", "%Go%"),
-		qm.Limit(5),
-	).All(ctx, db)
+		qm.Limit(cfg.App.SearchLimit),
+	).All(ctx, exec)
 	if err != nil {
 		log.Printf("クエリエラー: %v\n", err)
 		return
@@ -60,16 +112,17 @@ func main() {
 	// 結果の表示
 	fmt.Println("=== 全ての本 ===")
 	for _, b := range books {
-		fmt.Printf("ID: %d, Title: %s, Author: %s\n", b.ID, b.Title, b.Author)
+		fmt.Printf("ID: %d, Title: %s, Author: %s\n", b.ID, b.Title, b.AuthorName)
 	}
+	fmt.Printf("グローバル DB から取得した本: %d 件\n", len(booksG))
 
 	fmt.Println("\n=== 条件付きクエリの結果 ===")
 	for _, b := range booksWithCondition {
-		fmt.Printf("ID: %d, Title: %s, Author: %s\n", b.ID, b.Title, b.Author)
+		fmt.Printf("ID: %d, Title: %s, Author: %s\n", b.ID, b.Title, b.AuthorName)
 	}
 
 	// 検索関数の使用
-	searchResult, err := searchBooks(ctx, db, "Programming")
+	searchResult, err := searchBooks(ctx, exec, "Programming", cfg.App.SearchLimit)
 	if err != nil {
 		log.Printf("検索エラー: %v\n", err)
 		return
@@ -77,16 +130,17 @@ func main() {
 
 	fmt.Println("\n=== 検索結果 ===")
 	for _, b := range searchResult {
-		fmt.Printf("ID: %d, Title: %s, Author: %s\n", b.ID, b.Title, b.Author)
+		fmt.Printf("ID: %d, Title: %s, Author: %s\n", b.ID, b.Title, b.AuthorName)
 	}
 	// ---------------------------
 
 	// トランザクションの例
-	// db.BeginTx: データベーストランザクションを開始するメソッド
+	// exec.BeginTx: データベーストランザクションを開始するメソッド
+	// statement_timeout と lock_timeout もトランザクション内だけ設定される
 	// ctx: コンテキスト（タイムアウトや中断の制御に使用）
 	// nil: トランザクションのオプション（デフォルト設定を使用）
 	// 戻り値のtxはトランザクションオブジェクト
-	tx, err := db.BeginTx(ctx, nil)
+	tx, err := exec.BeginTx(ctx, nil)
 	if err != nil {
 		log.Printf("トランザクション開始エラー: %v\n", err)
 		return
@@ -100,6 +154,7 @@ func main() {
 		log.Printf("ユーザー取得エラー: %v\n", err)
 		return
 	}
+	fmt.Printf("\nトランザクション内で取得したユーザー: %d 人\n", len(usersInTx))
 
 	// トランザクションの利点：
 
@@ -116,25 +171,53 @@ func main() {
 	// 単一のユーザーを取得
 	// 以下のようなSQLと同じ:
 	// SELECT * FROM users LIMIT 1;
-	user, err := models.Users().One(ctx, db)
+	user, err := models.Users().One(ctx, exec)
 	if err != nil {
 		log.Printf("ユーザー取得エラー: %v\n", err)
 		return
 	}
 
-	// ユーザーのお気に入り映画を取得
-	movies, err := user.FavoriteMovies().All(ctx, db)
+	// ユーザーのお気に入り映画を新しく登録した順に取得
+	// 以下のようなSQLと同じ:
+	// SELECT movies.*, user_favorite_movies.created_at AS favorited_at FROM movies
+	// INNER JOIN user_favorite_movies ON user_favorite_movies.movie_id = movies.id
+	// WHERE user_favorite_movies.user_id = $1 ORDER BY favorited_at DESC;
+	favorites, err := user.FavoriteMovies(models.FavoritedAtDesc).All(ctx, exec)
 	if err != nil {
 		log.Printf("お気に入り映画取得エラー: %v\n", err)
 		return
 	}
+	fmt.Printf("\n=== %s のお気に入り映画 ===\n", user.Name)
+	for _, f := range favorites {
+		fmt.Printf("  - Movie: %s (%s)\n", f.Title, f.FavoritedAt.Time.Format("2006-01-02"))
+	}
+
+	// お気に入りの多い映画の 1 ページ目
+	popular, err := models.MostFavoritedMovies(models.Page(1, 5)).All(ctx, exec)
+	if err != nil {
+		log.Printf("人気映画取得エラー: %v\n", err)
+		return
+	}
+	fmt.Println("\n=== お気に入りの多い映画 ===")
+	for _, m := range popular {
+		fmt.Printf("  - %s: %d 人\n", m.Title, m.FavoriteCount)
+	}
 
 	// Eager loading（関連データの一括取得）の例
-	// SQLでは以下のようなJOINクエリに相当:
-	// SELECT users.*, movies.*
-	// FROM users
-	// JOIN movies ON users.id = movies.user_id;
-	usersWithMovies, err := models.Users(qm.Load("FavoriteMovies")).All(ctx, db)
+	// 以下の3つのSQLを順に実行する（JOINではなく階層ごとに1クエリ、親の件数によらない）:
+	// SELECT * FROM users;
+	// SELECT * FROM user_favorite_movies WHERE user_id IN (...);
+	// SELECT * FROM movies WHERE id IN (...);
+	// qm.Load の2番目以降の引数はその階層のクエリに適用される。
+	// ここでは2000年より後の映画だけを題名順に、ユーザーごとに3件まで読む。
+	usersWithMovies, err := models.Users(
+		qm.Load(models.UserRels.UserFavoriteMovies,
+			models.JoinFavoritedMovie(),
+			models.MovieWhere.ReleaseYear.GT(null.IntFrom(2000)),
+			models.LimitPerParent(3, models.UserFavoriteMovieTableColumns.UserID, "\"movies\".\"title\" ASC"),
+		),
+		qm.Load(qm.Rels(models.UserRels.UserFavoriteMovies, models.UserFavoriteMovieRels.Movie)),
+	).All(ctx, exec)
 	if err != nil {
 		log.Printf("ユーザーと映画の取得エラー: %v\n", err)
 		return
@@ -145,22 +228,55 @@ func main() {
 	// SQLクエリを構築するためのヘルパーパッケージ
 	// SQLの各部分（WHERE, ORDER BY, LIMIT など）を簡単に書けるようにする
 	// Load関数の基本
-	// qm.Load("FavoriteMovies")
+	// qm.Load("UserFavoriteMovies.Movie")
 	// Loadは関連するデータを一緒に取得するための機能
-	// 引数の"FavoriteMovies"は関連テーブルの名前
+	// 引数はリレーション名で、qm.Rels でドット区切りにつなぐとネストしたリレーションも読める
 
 	// 結果の表示
 	fmt.Println("\n=== ユーザーとお気に入り映画 ===")
 	for _, u := range usersWithMovies {
 		fmt.Printf("User ID: %d, Name: %s\n", u.ID, u.Name)
-		if u.R != nil && u.R.FavoriteMovies != nil {
-			fmt.Printf("お気に入り映画数: %d\n", len(u.R.FavoriteMovies))
-			for _, m := range u.R.FavoriteMovies {
-				fmt.Printf("  - Movie: %s\n", m.Title)
+		if u.R != nil && u.R.UserFavoriteMovies != nil {
+			fmt.Printf("2000年より後のお気に入り映画（最大3件）: %d 件\n", len(u.R.UserFavoriteMovies))
+			for _, f := range u.R.UserFavoriteMovies {
+				fmt.Printf("  - Movie: %s\n", f.R.Movie.Title)
 			}
 		}
 	}
 
+	// API で返す形（email を除き、読み込んだお気に入り映画を埋め込む）にして、必要なフィールドだけ JSON にする
+	usersJSON, err := dto.Marshal(dto.NewUserPublics(usersWithMovies), dto.Options{
+		Case:   dto.CamelCase,
+		Fields: dto.ParseFields("id,name,favoriteMovies.movie.title"),
+	})
+	if err != nil {
+		log.Printf("JSON 変換エラー: %v\n", err)
+		return
+	}
+	fmt.Printf("JSON: %s\n", usersJSON)
+
+	// お気に入り映画の数だけが必要なら、映画を読み込まずに件数だけを数える。並べ替えには集計を結合する
+	// SELECT users.* FROM users
+	// LEFT JOIN (SELECT user_id, count(*) AS count FROM user_favorite_movies GROUP BY user_id) AS user_favorite_movies_counts
+	// ON user_favorite_movies_counts.user_id = users.id ORDER BY coalesce(user_favorite_movies_counts.count, 0) DESC;
+	usersWithCounts, err := models.Users(
+		models.JoinFavoriteMoviesCount(),
+		models.OrderByFavoriteMoviesCount(true),
+	).All(ctx, exec)
+	if err != nil {
+		log.Printf("お気に入り数取得エラー: %v\n", err)
+		return
+	}
+	favoriteCounts, err := usersWithCounts.FavoriteMoviesCounts(ctx, exec)
+	if err != nil {
+		log.Printf("お気に入り数取得エラー: %v\n", err)
+		return
+	}
+	fmt.Println("\n=== ユーザーごとのお気に入り映画数 ===")
+	for _, u := range usersWithCounts {
+		fmt.Printf("User ID: %d, Name: %s, お気に入り映画数: %d\n", u.ID, u.Name, favoriteCounts[u.ID])
+	}
+
 	// トランザクションのコミット
 	if err := tx.Commit(); err != nil {
 		log.Printf("トランザクションコミットエラー: %v\n", err)
@@ -0,0 +1,135 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// リレーションの件数を、子を読み込まずに数える。
+//
+// UserSlice.FavoriteMoviesCounts などは、読み込んだ行の件数を 1 本の GROUP BY のクエリで数え、ID をキーにした map で返す。
+// 件数で絞り込んだり並べ替えたりするときは JoinXxxCount で件数を集計したサブクエリを 1 回だけ LEFT JOIN し、
+// XxxCountWhere や OrderByXxxCount はその列を参照する。
+//
+//	users, err := Users(
+//		JoinFavoriteMoviesCount(),
+//		UserCountWhere.FavoriteMovies.GTE(5),
+//		OrderByFavoriteMoviesCount(true),
+//	).All(ctx, exec)
+//	counts, err := users.FavoriteMoviesCounts(ctx, exec)
+//	// counts[users[0].ID]
+
+// 件数を集計したサブクエリの結合と、結合した件数（お気に入りが無ければ 0）
+const (
+	userFavoriteMoviesCountJoin = `(SELECT "user_id", count(*) AS "count" FROM "user_favorite_movies" GROUP BY "user_id") AS "user_favorite_movies_counts" ON "user_favorite_movies_counts"."user_id" = "users"."id"`
+	userFavoriteMoviesCountExpr = `coalesce("user_favorite_movies_counts"."count", 0)`
+
+	movieFavoritedByCountJoin = `(SELECT "movie_id", count(*) AS "count" FROM "user_favorite_movies" GROUP BY "movie_id") AS "movie_favorited_by_counts" ON "movie_favorited_by_counts"."movie_id" = "movies"."id"`
+	movieFavoritedByCountExpr = `coalesce("movie_favorited_by_counts"."count", 0)`
+)
+
+func orderByCount(expr string, desc bool) qm.QueryMod {
+	if desc {
+		return qm.OrderBy(expr + " DESC")
+	}
+	return qm.OrderBy(expr + " ASC")
+}
+
+// countFavorites は user_favorite_movies の行を col の値ごとに数える。ids のうち 0 件の値も 0 として結果に含める。
+func countFavorites(ctx context.Context, e boil.ContextExecutor, col string, ids []int) (map[int]int64, error) {
+	counts := make(map[int]int64, len(ids))
+	if len(ids) == 0 {
+		return counts, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+		counts[id] = 0
+	}
+
+	query := NewQuery(
+		qm.Select(col, "count(*)"),
+		qm.From(`user_favorite_movies`),
+		qm.WhereIn(fmt.Sprintf(`user_favorite_movies.%s in ?`, col), args...),
+		qm.GroupBy(col),
+	)
+
+	rows, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to count user_favorite_movies")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var n int64
+		if err = rows.Scan(&id, &n); err != nil {
+			return nil, errors.Wrap(err, "failed to scan user_favorite_movies counts")
+		}
+		counts[id] = n
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error occurred during iteration of user_favorite_movies counts")
+	}
+
+	return counts, nil
+}
+
+// UserCountWhere はリレーションの件数で users を絞り込む。JoinXxxCount と一緒に使う。
+var UserCountWhere = struct {
+	FavoriteMovies whereHelperint
+}{
+	FavoriteMovies: whereHelperint{field: userFavoriteMoviesCountExpr},
+}
+
+// MovieCountWhere はリレーションの件数で movies を絞り込む。JoinXxxCount と一緒に使う。
+var MovieCountWhere = struct {
+	FavoritedBy whereHelperint
+}{
+	FavoritedBy: whereHelperint{field: movieFavoritedByCountExpr},
+}
+
+// JoinFavoriteMoviesCount はお気に入りの映画の数を結合し、UserCountWhere.FavoriteMovies と OrderByFavoriteMoviesCount で使えるようにする
+func JoinFavoriteMoviesCount() qm.QueryMod {
+	return qm.LeftOuterJoin(userFavoriteMoviesCountJoin)
+}
+
+// OrderByFavoriteMoviesCount はお気に入りの映画の数で並べる。JoinFavoriteMoviesCount と一緒に使う。
+func OrderByFavoriteMoviesCount(desc bool) qm.QueryMod {
+	return orderByCount(userFavoriteMoviesCountExpr, desc)
+}
+
+// FavoriteMoviesCounts はユーザーごとのお気に入りの映画の数を、ユーザーの ID をキーにして返す
+func (o UserSlice) FavoriteMoviesCounts(ctx context.Context, exec boil.ContextExecutor) (map[int]int64, error) {
+	ids := make([]int, len(o))
+	for i, obj := range o {
+		ids[i] = obj.ID
+	}
+
+	return countFavorites(ctx, exec, "user_id", ids)
+}
+
+// JoinFavoritedByCount はお気に入りに登録したユーザーの数を結合し、MovieCountWhere.FavoritedBy と OrderByFavoritedByCount で使えるようにする
+func JoinFavoritedByCount() qm.QueryMod {
+	return qm.LeftOuterJoin(movieFavoritedByCountJoin)
+}
+
+// OrderByFavoritedByCount はお気に入りに登録したユーザーの数で並べる。JoinFavoritedByCount と一緒に使う。
+func OrderByFavoritedByCount(desc bool) qm.QueryMod {
+	return orderByCount(movieFavoritedByCountExpr, desc)
+}
+
+// FavoritedByCounts は映画ごとにお気に入りに登録したユーザーの数を、映画の ID をキーにして返す
+func (o MovieSlice) FavoritedByCounts(ctx context.Context, exec boil.ContextExecutor) (map[int]int64, error) {
+	ids := make([]int, len(o))
+	for i, obj := range o {
+		ids[i] = obj.ID
+	}
+
+	return countFavorites(ctx, exec, "movie_id", ids)
+}
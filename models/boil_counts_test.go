@@ -0,0 +1,94 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+func TestJoinFavoriteMoviesCountQuery(t *testing.T) {
+	t.Parallel()
+
+	q := Users(
+		JoinFavoriteMoviesCount(),
+		UserCountWhere.FavoriteMovies.GTE(5),
+		OrderByFavoriteMoviesCount(true),
+	)
+
+	// 件数は結合した集計を参照し、相関副問い合わせは使わない
+	sql, args := queries.BuildQuery(q.Query)
+	want := `SELECT "users".* FROM "users" LEFT JOIN ` + userFavoriteMoviesCountJoin + ` ` +
+		`WHERE (` + userFavoriteMoviesCountExpr + ` >= $1) ORDER BY ` + userFavoriteMoviesCountExpr + ` DESC;`
+	if sql != want {
+		t.Errorf("got:\n%s\nwant:\n%s", sql, want)
+	}
+	if len(args) != 1 || args[0] != 5 {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestUsersWithCounts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	fan, movies := insertFavoriteFixtures(t, ctx, tx, 3)
+	casual, _ := insertFavoriteFixtures(t, ctx, tx, 0)
+	none, _ := insertFavoriteFixtures(t, ctx, tx, 0)
+
+	for _, m := range movies {
+		if _, err := fan.AddFavorite(ctx, tx, m.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := casual.AddFavorite(ctx, tx, movies[0].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []int{fan.ID, casual.ID, none.ID}
+	users, err := Users(
+		UserWhere.ID.IN(ids),
+		JoinFavoriteMoviesCount(),
+		OrderByFavoriteMoviesCount(true),
+	).All(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts, err := users.FavoriteMoviesCounts(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		id    int
+		count int64
+	}{{fan.ID, 3}, {casual.ID, 1}, {none.ID, 0}}
+	if len(users) != len(want) {
+		t.Fatalf("want %d users, got %d", len(want), len(users))
+	}
+	for i, w := range want {
+		if users[i].ID != w.id || counts[users[i].ID] != w.count {
+			t.Errorf("users[%d] = %d (%d), want %d (%d)", i, users[i].ID, counts[users[i].ID], w.id, w.count)
+		}
+	}
+
+	atLeastOne, err := Users(UserWhere.ID.IN(ids), JoinFavoriteMoviesCount(), UserCountWhere.FavoriteMovies.GTE(1)).Count(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if atLeastOne != 2 {
+		t.Errorf("want 2 users with a favorite, got %d", atLeastOne)
+	}
+
+	favorited, err := movies.FavoritedByCounts(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if favorited[movies[0].ID] != 2 || favorited[movies[1].ID] != 1 {
+		t.Errorf("want movie %d favorited by 2 and movie %d by 1, got %v", movies[0].ID, movies[1].ID, favorited)
+	}
+}
@@ -0,0 +1,215 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// 生成された Delete は子の行を気にせず親を削除するので、子が残っていると外部キー違反になる。
+// DeleteWith は子の扱い（DeleteBehavior）を指定して削除する。
+// 子の削除・更新と親の削除は同じ exec で順に実行するので、途中で失敗したときに
+// 巻き戻せるようトランザクションを渡すこと。
+//
+// user_favorite_movies の外部キーに ON DELETE CASCADE を付けるマイグレーションは任意なので、番号の付いた
+// db/migrations には入れておらず、自動では適用されない。採用するときは手で
+// db/migrations/optional/user_favorite_movies_on_delete_cascade.up.sql に次の番号（000011_ など）を付けて
+// db/migrations に移し、マイグレーションを流す。採用後も DeleteWith はそのまま使え、生成された Delete も
+// お気に入りが残るユーザー・映画を削除できるようになる。
+
+// DeleteBehavior は親を削除するときの子の行の扱い
+type DeleteBehavior int
+
+// 子の行の扱い
+const (
+	// DeleteRestrict は子の行が残っていれば削除せず RestrictError を返す
+	DeleteRestrict DeleteBehavior = iota
+	// DeleteCascade は子の行を先に削除する（子の削除フックも実行する）
+	DeleteCascade
+	// DeleteNullify は子の外部キーを NULL にする（子の更新フックも実行する）。
+	// NULL にできない外部キーがあればエラーにする。
+	DeleteNullify
+)
+
+func (b DeleteBehavior) String() string {
+	switch b {
+	case DeleteCascade:
+		return "cascade"
+	case DeleteNullify:
+		return "nullify"
+	default:
+		return "restrict"
+	}
+}
+
+// Blocker は削除を妨げている子の行
+type Blocker struct {
+	Relationship string
+	Table        string
+	Count        int64
+}
+
+// RestrictError は子の行が残っているため DeleteRestrict で削除しなかったときのエラー
+type RestrictError struct {
+	Table    string
+	Blockers []Blocker
+}
+
+func (e *RestrictError) Error() string {
+	blockers := make([]string, len(e.Blockers))
+	for i, b := range e.Blockers {
+		blockers[i] = fmt.Sprintf("%s (%d rows in %s)", b.Relationship, b.Count, b.Table)
+	}
+
+	return fmt.Sprintf("models: cannot delete from %s: still referenced by %s", e.Table, strings.Join(blockers, ", "))
+}
+
+// childRelation は親を参照する子の行（to-many のリレーション）
+type childRelation struct {
+	name     string
+	table    string
+	column   string
+	nullable bool
+
+	count   func(ctx context.Context, exec boil.ContextExecutor) (int64, error)
+	cascade func(ctx context.Context, exec boil.ContextExecutor) error
+	nullify func(ctx context.Context, exec boil.ContextExecutor) error
+}
+
+// deleteWith は behavior に従って子の行を片付けてから del で親を削除する
+func deleteWith(ctx context.Context, exec boil.ContextExecutor, table string, behavior DeleteBehavior,
+	rels []childRelation, del func() (int64, error)) (int64, error) {
+	switch behavior {
+	case DeleteRestrict:
+		err := &RestrictError{Table: table}
+		for _, rel := range rels {
+			n, cerr := rel.count(ctx, exec)
+			if cerr != nil {
+				return 0, errors.Wrapf(cerr, "models: unable to count %s", rel.table)
+			}
+			if n != 0 {
+				err.Blockers = append(err.Blockers, Blocker{Relationship: rel.name, Table: rel.table, Count: n})
+			}
+		}
+		if len(err.Blockers) != 0 {
+			return 0, err
+		}
+	case DeleteCascade:
+		for _, rel := range rels {
+			if err := rel.cascade(ctx, exec); err != nil {
+				return 0, errors.Wrapf(err, "models: unable to cascade delete to %s", rel.table)
+			}
+		}
+	case DeleteNullify:
+		for _, rel := range rels {
+			if !rel.nullable {
+				return 0, errors.Errorf("models: cannot nullify %s.%s: column is not nullable", rel.table, rel.column)
+			}
+		}
+		for _, rel := range rels {
+			if err := rel.nullify(ctx, exec); err != nil {
+				return 0, errors.Wrapf(err, "models: unable to nullify %s.%s", rel.table, rel.column)
+			}
+		}
+	default:
+		return 0, errors.Errorf("models: unknown delete behavior %d", behavior)
+	}
+
+	return del()
+}
+
+// DeleteWith は子の行を behavior に従って扱ってから o を削除する
+func (o *User) DeleteWith(ctx context.Context, exec boil.ContextExecutor, behavior DeleteBehavior) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no User provided for delete")
+	}
+
+	rels := []childRelation{{
+		name:   UserRels.UserFavoriteMovies,
+		table:  TableNames.UserFavoriteMovies,
+		column: UserFavoriteMovieColumns.UserID,
+		count: func(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+			return o.UserFavoriteMovies().Count(ctx, exec)
+		},
+		cascade: func(ctx context.Context, exec boil.ContextExecutor) error {
+			favs, err := o.UserFavoriteMovies().All(ctx, exec)
+			if err != nil {
+				return err
+			}
+			_, err = favs.DeleteAll(ctx, exec)
+			return err
+		},
+	}}
+
+	return deleteWith(ctx, exec, TableNames.Users, behavior, rels, func() (int64, error) {
+		return o.Delete(ctx, exec)
+	})
+}
+
+// DeleteWith は子の行を behavior に従って扱ってから o を削除する
+func (o *Movie) DeleteWith(ctx context.Context, exec boil.ContextExecutor, behavior DeleteBehavior) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Movie provided for delete")
+	}
+
+	rels := []childRelation{{
+		name:   MovieRels.UserFavoriteMovies,
+		table:  TableNames.UserFavoriteMovies,
+		column: UserFavoriteMovieColumns.MovieID,
+		count: func(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+			return o.UserFavoriteMovies().Count(ctx, exec)
+		},
+		cascade: func(ctx context.Context, exec boil.ContextExecutor) error {
+			favs, err := o.UserFavoriteMovies().All(ctx, exec)
+			if err != nil {
+				return err
+			}
+			_, err = favs.DeleteAll(ctx, exec)
+			return err
+		},
+	}}
+
+	return deleteWith(ctx, exec, TableNames.Movies, behavior, rels, func() (int64, error) {
+		return o.Delete(ctx, exec)
+	})
+}
+
+// DeleteWith は子の行を behavior に従って扱ってから o を削除する。
+// DeleteNullify では著者の本を残し、books.author_id だけを NULL にする。
+func (o *Author) DeleteWith(ctx context.Context, exec boil.ContextExecutor, behavior DeleteBehavior) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Author provided for delete")
+	}
+
+	rels := []childRelation{{
+		name:     AuthorRels.Books,
+		table:    TableNames.Books,
+		column:   BookColumns.AuthorID,
+		nullable: true,
+		count: func(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+			return o.Books().Count(ctx, exec)
+		},
+		cascade: func(ctx context.Context, exec boil.ContextExecutor) error {
+			books, err := o.Books().All(ctx, exec)
+			if err != nil {
+				return err
+			}
+			_, err = books.DeleteAll(ctx, exec)
+			return err
+		},
+		nullify: func(ctx context.Context, exec boil.ContextExecutor) error {
+			books, err := o.Books().All(ctx, exec)
+			if err != nil {
+				return err
+			}
+			return o.RemoveBooks(ctx, exec, books...)
+		},
+	}}
+
+	return deleteWith(ctx, exec, TableNames.Authors, behavior, rels, func() (int64, error) {
+		return o.Delete(ctx, exec)
+	})
+}
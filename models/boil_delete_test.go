@@ -0,0 +1,175 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// fakeRelation は呼ばれた操作を calls に記録する childRelation を作る
+func fakeRelation(name string, n int64, nullable bool, calls *[]string) childRelation {
+	return childRelation{
+		name:     name,
+		table:    name,
+		column:   "parent_id",
+		nullable: nullable,
+		count: func(context.Context, boil.ContextExecutor) (int64, error) {
+			*calls = append(*calls, "count "+name)
+			return n, nil
+		},
+		cascade: func(context.Context, boil.ContextExecutor) error {
+			*calls = append(*calls, "cascade "+name)
+			return nil
+		},
+		nullify: func(context.Context, boil.ContextExecutor) error {
+			*calls = append(*calls, "nullify "+name)
+			return nil
+		},
+	}
+}
+
+func TestDeleteWithBehaviors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		behavior DeleteBehavior
+		nullable bool
+		want     []string
+		wantErr  bool
+	}{
+		{DeleteRestrict, false, []string{"count a", "count b"}, true},
+		{DeleteCascade, false, []string{"cascade a", "cascade b", "delete"}, false},
+		{DeleteNullify, true, []string{"nullify a", "nullify b", "delete"}, false},
+		{DeleteNullify, false, nil, true},
+	}
+
+	for _, tt := range tests {
+		var calls []string
+		rels := []childRelation{
+			fakeRelation("a", 0, tt.nullable, &calls),
+			fakeRelation("b", 2, tt.nullable, &calls),
+		}
+
+		_, err := deleteWith(context.Background(), nil, "parents", tt.behavior, rels, func() (int64, error) {
+			calls = append(calls, "delete")
+			return 1, nil
+		})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s (nullable %t): err = %v", tt.behavior, tt.nullable, err)
+		}
+		if !reflect.DeepEqual(calls, tt.want) {
+			t.Errorf("%s (nullable %t): calls = %v, want %v", tt.behavior, tt.nullable, calls, tt.want)
+		}
+	}
+}
+
+func TestRestrictErrorListsBlockers(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	rels := []childRelation{
+		fakeRelation("a", 0, false, &calls),
+		fakeRelation("b", 2, false, &calls),
+	}
+
+	_, err := deleteWith(context.Background(), nil, "parents", DeleteRestrict, rels, func() (int64, error) {
+		t.Fatal("parent should not be deleted")
+		return 0, nil
+	})
+
+	var re *RestrictError
+	if !errors.As(err, &re) {
+		t.Fatalf("want RestrictError, got %v", err)
+	}
+	if len(re.Blockers) != 1 || re.Blockers[0] != (Blocker{Relationship: "b", Table: "b", Count: 2}) {
+		t.Errorf("blockers = %v", re.Blockers)
+	}
+	if want := "models: cannot delete from parents: still referenced by b (2 rows in b)"; err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+}
+
+func TestUserDeleteWith(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	u, movies := insertFavoriteFixtures(t, ctx, tx, 2)
+	for _, m := range movies {
+		if _, err := u.AddFavorite(ctx, tx, m.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := u.DeleteWith(ctx, tx, DeleteRestrict)
+	var re *RestrictError
+	if !errors.As(err, &re) {
+		t.Fatalf("want RestrictError, got %v", err)
+	}
+	if len(re.Blockers) != 1 || re.Blockers[0].Relationship != UserRels.UserFavoriteMovies || re.Blockers[0].Count != 2 {
+		t.Errorf("blockers = %v", re.Blockers)
+	}
+
+	if _, err := u.DeleteWith(ctx, tx, DeleteNullify); err == nil {
+		t.Error("nullify should fail for user_favorite_movies.user_id")
+	}
+
+	rowsAff, err := u.DeleteWith(ctx, tx, DeleteCascade)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rowsAff != 1 {
+		t.Errorf("want 1 user deleted, got %d", rowsAff)
+	}
+
+	left, err := UserFavoriteMovies(UserFavoriteMovieWhere.UserID.EQ(u.ID)).Count(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if left != 0 {
+		t.Errorf("want favorites deleted, %d left", left)
+	}
+
+	if n, err := Movies(MovieWhere.ID.EQ(movies[0].ID)).Count(ctx, tx); err != nil || n != 1 {
+		t.Errorf("movies should be kept: count %d, err %v", n, err)
+	}
+}
+
+func TestAuthorDeleteWithNullify(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	author, err := FindOrCreateAuthor(ctx, tx, "Stanisław Lem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	book := &Book{Title: "Solaris", AuthorName: author.Name}
+	if err := book.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err := book.SetAuthor(ctx, tx, false, author); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := author.DeleteWith(ctx, tx, DeleteRestrict); err == nil {
+		t.Fatal("restrict should refuse an author with books")
+	}
+
+	if _, err := author.DeleteWith(ctx, tx, DeleteNullify); err != nil {
+		t.Fatal(err)
+	}
+	if err := book.Reload(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	if book.AuthorID.Valid {
+		t.Errorf("want author_id nullified, got %v", book.AuthorID)
+	}
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"database/sql/driver"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// 変更追跡。FindX・One・All・ReloadAll や eager load で読み込んだ時点の値（スナップショット）を
+// モデルに持っておき、Changed で差分を、UpdateChanged で変わったカラムだけの UPDATE を行う。
+// スナップショットは AfterSelect フックの後に取るので、フックが変えた値は変更とみなさない。
+// テーブルごとのメソッドは templates/main/16_update.go.tpl から生成する。
+
+// ColumnChange は 1 カラムの変更前後の値。値はデータベースに渡す形（null.Int なら int64 か nil）で持つ。
+type ColumnChange struct {
+	Column string
+	Old    interface{}
+	New    interface{}
+}
+
+// diffColumns は cols の順に before から after への変更を返す
+func diffColumns(cols []string, before, after []interface{}) []ColumnChange {
+	var changes []ColumnChange
+	for i, col := range cols {
+		b, a := driverValue(before[i]), driverValue(after[i])
+		// queries.Equal は NULL 同士を等しいとみなさない
+		if b == nil && a == nil {
+			continue
+		}
+		if !queries.Equal(b, a) {
+			changes = append(changes, ColumnChange{Column: col, Old: b, New: a})
+		}
+	}
+
+	return changes
+}
+
+// driverValue は null.Int などをデータベースに渡す値（NULL なら nil）にする
+func driverValue(v interface{}) interface{} {
+	valuer, ok := v.(driver.Valuer)
+	if !ok {
+		return v
+	}
+
+	dv, err := valuer.Value()
+	if err != nil {
+		return v
+	}
+	return dv
+}
+
+// changedColumns は changes のカラム名を返す。主キーが変わっていればエラーにする。
+func changedColumns(table string, changes []ColumnChange, pkeys []string) ([]string, error) {
+	cols := make([]string, len(changes))
+	for i, c := range changes {
+		for _, pk := range pkeys {
+			if c.Column == pk {
+				return nil, errors.Errorf("models: cannot update primary key column %s of %s", pk, table)
+			}
+		}
+		cols[i] = c.Column
+	}
+
+	return cols, nil
+}
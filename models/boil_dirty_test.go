@@ -0,0 +1,22 @@
+package models
+
+import (
+	"context"
+	"testing"
+)
+
+func TestUpdateChangedPrimaryKey(t *testing.T) {
+	t.Parallel()
+
+	// 変更追跡は books 以外のテーブルにも生成される
+	o := &Movie{ID: 1, Title: "Alien"}
+	o.Track()
+	o.ID = 2
+
+	if _, err := o.UpdateChanged(context.Background(), nil); err == nil {
+		t.Error("changing the primary key should be rejected")
+	}
+	if changes := o.Changed(); len(changes) != 1 || changes[0].Column != MovieColumns.ID {
+		t.Errorf("got %v", changes)
+	}
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/strmangle"
+)
+
+// qm.Load(rel, mods...) の mods は、生成された LoadXxx が組み立てる
+// SELECT * FROM <子テーブル> WHERE <外部キー> IN (<親の主キー>) に適用される。
+// 親が何件あっても 1 階層につき 1 クエリで、qm.Rels でつないだ階層ごとに同じことが繰り返される。
+// ここではその mods として使う、絞り込み・並べ替え・親ごとの件数制限を提供する。
+
+// queryMods は複数の mod を 1 つにまとめる（qm.Expr は WHERE 句のグループ用なので使えない）
+type queryMods []qm.QueryMod
+
+// Apply implements qm.QueryMod.Apply.
+func (m queryMods) Apply(q *queries.Query) {
+	for _, mod := range m {
+		mod.Apply(q)
+	}
+}
+
+// JoinFavoritedMovie は user_favorite_movies の eager loading で movies を結合する。
+// 選択する列は user_favorite_movies のものだけにするので、MovieWhere や movies の列での
+// 並べ替えを使ってお気に入りを絞り込める（movies 自体は Movie の階層で読む）。
+func JoinFavoritedMovie() qm.QueryMod {
+	return queryMods{
+		qm.Select("\"user_favorite_movies\".*"),
+		qm.InnerJoin("\"movies\" on \"movies\".\"id\" = \"user_favorite_movies\".\"movie_id\""),
+	}
+}
+
+// JoinFavoritingUser は JoinFavoritedMovie の users 版
+func JoinFavoritingUser() qm.QueryMod {
+	return queryMods{
+		qm.Select("\"user_favorite_movies\".*"),
+		qm.InnerJoin("\"users\" on \"users\".\"id\" = \"user_favorite_movies\".\"user_id\""),
+	}
+}
+
+// LimitPerParentMod は eager loading で読む子を親ごとに n 件までに絞る mod
+type LimitPerParentMod struct {
+	n       int
+	fkey    string
+	orderBy []string
+}
+
+// LimitPerParent は eager loading で読む子を、外部キー fkey（"table.column"）が同じ行ごとに
+// orderBy の順で n 件までに絞る。row_number() のウィンドウ関数を使うので 1 クエリのまま。
+//
+// 組み立て済みのクエリを副問い合わせに包むため、qm.Load の mods の最後に置くこと。
+// 例: 各ユーザーの 2000 年以降の映画を題名順に 3 件まで
+//
+//	qm.Load(UserRels.UserFavoriteMovies,
+//		JoinFavoritedMovie(),
+//		MovieWhere.ReleaseYear.GT(null.IntFrom(2000)),
+//		LimitPerParent(3, UserFavoriteMovieTableColumns.UserID, "\"movies\".\"title\" ASC"),
+//	)
+func LimitPerParent(n int, fkey string, orderBy ...string) LimitPerParentMod {
+	return LimitPerParentMod{n: n, fkey: fkey, orderBy: orderBy}
+}
+
+// Apply implements qm.QueryMod.Apply.
+func (m LimitPerParentMod) Apply(q *queries.Query) {
+	table := m.fkey
+	if i := strings.LastIndexByte(table, '.'); i >= 0 {
+		table = table[:i]
+	}
+	table = strings.Trim(table, "\"")
+
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{fmt.Sprintf("%s.*", strmangle.IdentQuote(dialect.LQ, dialect.RQ, table))})
+	}
+
+	over := "PARTITION BY " + strmangle.IdentQuote(dialect.LQ, dialect.RQ, m.fkey)
+	if len(m.orderBy) != 0 {
+		over += " ORDER BY " + strings.Join(m.orderBy, ", ")
+	}
+	queries.AppendSelect(q, fmt.Sprintf("row_number() OVER (%s) as \"_row_number\"", over))
+
+	inner, args := queries.BuildQuery(q)
+	inner = strings.TrimSuffix(inner, ";")
+
+	// 親ごとの並び順を保つため、外側は行番号順に返す。生成された LoadXxx はこの順に親へ追加する。
+	outer := fmt.Sprintf("SELECT * FROM (%s) as %s WHERE \"_row_number\" <= %d ORDER BY \"_row_number\";",
+		inner, strmangle.IdentQuote(dialect.LQ, dialect.RQ, table), m.n)
+	queries.SetSQL(q, outer, args...)
+}
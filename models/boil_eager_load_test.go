@@ -0,0 +1,144 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+func TestLimitPerParentQuery(t *testing.T) {
+	t.Parallel()
+
+	// 生成された LoadUserFavoriteMovies と同じ形のクエリに mods を適用する
+	q := NewQuery(
+		qm.From(`user_favorite_movies`),
+		qm.WhereIn(`user_favorite_movies.user_id in ?`, 1, 2),
+	)
+	queryMods{
+		JoinFavoritedMovie(),
+		MovieWhere.ReleaseYear.GT(null.IntFrom(2000)),
+		LimitPerParent(3, UserFavoriteMovieTableColumns.UserID, "\"movies\".\"title\" ASC"),
+	}.Apply(q)
+
+	sql, args := queries.BuildQuery(q)
+	want := `SELECT * FROM (SELECT "user_favorite_movies".*, ` +
+		`row_number() OVER (PARTITION BY "user_favorite_movies"."user_id" ORDER BY "movies"."title" ASC) as "_row_number" ` +
+		`FROM "user_favorite_movies" INNER JOIN "movies" on "movies"."id" = "user_favorite_movies"."movie_id" ` +
+		`WHERE ("user_favorite_movies"."user_id" IN ($1,$2)) AND ("movies"."release_year" > $3)) as "user_favorite_movies" ` +
+		`WHERE "_row_number" <= 3 ORDER BY "_row_number";`
+	if sql != want {
+		t.Errorf("got:\n%s\nwant:\n%s", sql, want)
+	}
+	if len(args) != 3 {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestLimitPerParentSelectsTableWithoutSelect(t *testing.T) {
+	t.Parallel()
+
+	q := NewQuery(qm.From(`books`))
+	LimitPerParent(1, "books.author").Apply(q)
+
+	sql, _ := queries.BuildQuery(q)
+	want := `SELECT * FROM (SELECT "books".*, row_number() OVER (PARTITION BY "books"."author") as "_row_number" ` +
+		`FROM "books") as "books" WHERE "_row_number" <= 1 ORDER BY "_row_number";`
+	if sql != want {
+		t.Errorf("got:\n%s\nwant:\n%s", sql, want)
+	}
+}
+
+// countingExecutor は実行したクエリの数を数える
+type countingExecutor struct {
+	boil.ContextExecutor
+	queries int64
+}
+
+func (c *countingExecutor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	atomic.AddInt64(&c.queries, 1)
+	return c.ContextExecutor.QueryContext(ctx, query, args...)
+}
+
+func TestEagerLoadFilteredNestedOneQueryPerLevel(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var users UserSlice
+	var movies MovieSlice
+	for i := 0; i < 3; i++ {
+		u, ms := insertFavoriteFixtures(t, ctx, tx, 4)
+		users = append(users, u)
+		movies = append(movies, ms...)
+	}
+
+	// 各ユーザーに全映画をお気に入り登録し、半分の映画を 2000 年より前にする
+	for i, m := range movies {
+		m.ReleaseYear = null.IntFrom(1990 + (i%2)*20)
+		if _, err := m.Update(ctx, tx, boil.Whitelist(MovieColumns.ReleaseYear)); err != nil {
+			t.Fatal(err)
+		}
+		for _, u := range users {
+			if _, err := u.AddFavorite(ctx, tx, m.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	ids := make([]int, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+
+	exec := &countingExecutor{ContextExecutor: tx}
+	loaded, err := Users(
+		UserWhere.ID.IN(ids),
+		qm.Load(UserRels.UserFavoriteMovies,
+			JoinFavoritedMovie(),
+			MovieWhere.ReleaseYear.GT(null.IntFrom(2000)),
+			LimitPerParent(3, UserFavoriteMovieTableColumns.UserID, "\"movies\".\"id\" DESC"),
+		),
+		qm.Load(qm.Rels(UserRels.UserFavoriteMovies, UserFavoriteMovieRels.Movie)),
+	).All(ctx, exec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// users、user_favorite_movies、movies の 3 クエリ
+	if n := atomic.LoadInt64(&exec.queries); n != 3 {
+		t.Errorf("want 3 queries, got %d", n)
+	}
+
+	if len(loaded) != len(users) {
+		t.Fatalf("want %d users, got %d", len(users), len(loaded))
+	}
+	for _, u := range loaded {
+		favs := u.R.UserFavoriteMovies
+		if len(favs) != 3 {
+			t.Errorf("user %d: want 3 favorites, got %d", u.ID, len(favs))
+			continue
+		}
+		movieIDs := make([]int, len(favs))
+		for i, f := range favs {
+			if f.R == nil || f.R.Movie == nil {
+				t.Fatalf("user %d: movie not loaded", u.ID)
+			}
+			if !f.R.Movie.ReleaseYear.Valid || f.R.Movie.ReleaseYear.Int <= 2000 {
+				t.Errorf("user %d: movie %d released %v", u.ID, f.MovieID, f.R.Movie.ReleaseYear)
+			}
+			movieIDs[i] = f.MovieID
+		}
+		if !sort.SliceIsSorted(movieIDs, func(i, j int) bool { return movieIDs[i] > movieIDs[j] }) {
+			t.Errorf("user %d: favorites not in movie id order: %v", u.ID, movieIDs)
+		}
+	}
+}
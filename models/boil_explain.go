@@ -0,0 +1,289 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// LargeTableRows は LargeSeqScans が大きいテーブルとみなす推定行数
+const LargeTableRows = 10000
+
+// QueryPlan は EXPLAIN (FORMAT JSON) の結果
+type QueryPlan struct {
+	// Query と Args は EXPLAIN したクエリと引数
+	Query string
+	Args  []interface{}
+
+	Plan PlanNode `json:"Plan"`
+	// PlanningTime と ExecutionTime はミリ秒。ExecutionTime は ANALYZE のときだけ入る。
+	PlanningTime  float64 `json:"Planning Time"`
+	ExecutionTime float64 `json:"Execution Time"`
+
+	// TableRows は計画に現れたテーブルの推定行数（pg_class.reltuples）。統計の無いテーブルは含まない。
+	TableRows map[string]float64
+}
+
+// PlanNode は実行計画の 1 ノード。Actual で始まるフィールドと RowsRemovedByFilter は ANALYZE のときだけ入る。
+type PlanNode struct {
+	NodeType     string `json:"Node Type"`
+	RelationName string `json:"Relation Name"`
+	Alias        string `json:"Alias"`
+	IndexName    string `json:"Index Name"`
+	JoinType     string `json:"Join Type"`
+
+	StartupCost float64 `json:"Startup Cost"`
+	TotalCost   float64 `json:"Total Cost"`
+	PlanRows    float64 `json:"Plan Rows"`
+	PlanWidth   int     `json:"Plan Width"`
+
+	ActualStartupTime float64 `json:"Actual Startup Time"`
+	ActualTotalTime   float64 `json:"Actual Total Time"`
+	ActualRows        float64 `json:"Actual Rows"`
+	ActualLoops       float64 `json:"Actual Loops"`
+
+	Filter              string   `json:"Filter"`
+	IndexCond           string   `json:"Index Cond"`
+	RowsRemovedByFilter float64  `json:"Rows Removed by Filter"`
+	SortKey             []string `json:"Sort Key"`
+
+	Plans []PlanNode `json:"Plans"`
+}
+
+// explainQuery は q を EXPLAIN (FORMAT JSON) して実行計画を返す。
+// analyze が true なら EXPLAIN ANALYZE でクエリを実際に実行する。
+func explainQuery(ctx context.Context, exec boil.ContextExecutor, q *queries.Query, analyze bool) (*QueryPlan, error) {
+	query, args := queries.BuildQuery(q)
+
+	options := "FORMAT JSON"
+	if analyze {
+		options = "ANALYZE, " + options
+	}
+	stmt := fmt.Sprintf("EXPLAIN (%s) %s", options, query)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, stmt)
+		fmt.Fprintln(writer, args...)
+	}
+	var raw []byte
+	if err := exec.QueryRowContext(ctx, stmt, args...).Scan(&raw); err != nil {
+		return nil, errors.Wrap(err, "models: unable to explain query")
+	}
+
+	plan, err := parseQueryPlan(raw)
+	if err != nil {
+		return nil, err
+	}
+	plan.Query, plan.Args = query, args
+
+	if plan.TableRows, err = tableRows(ctx, exec, plan.relations()); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// parseQueryPlan は EXPLAIN (FORMAT JSON) の出力を読む
+func parseQueryPlan(raw []byte) (*QueryPlan, error) {
+	var plans []*QueryPlan
+	if err := json.Unmarshal(raw, &plans); err != nil {
+		return nil, errors.Wrap(err, "models: unable to parse query plan")
+	}
+	if len(plans) != 1 {
+		return nil, errors.Errorf("models: want 1 query plan, got %d", len(plans))
+	}
+
+	return plans[0], nil
+}
+
+// tableRows は relations の推定行数を pg_class から読む。ANALYZE されていないテーブル（reltuples < 0）は省く。
+func tableRows(ctx context.Context, exec boil.ContextExecutor, relations []string) (map[string]float64, error) {
+	ret := map[string]float64{}
+	if len(relations) == 0 {
+		return ret, nil
+	}
+
+	query := "SELECT \"relname\", \"reltuples\" FROM \"pg_class\" " +
+		"WHERE \"relname\" = ANY(string_to_array($1, ',')) AND pg_table_is_visible(\"oid\")"
+	arg := strings.Join(relations, ",")
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, arg)
+	}
+	rows, err := exec.QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: unable to read table statistics")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		var n float64
+		if err := rows.Scan(&name, &n); err != nil {
+			return nil, errors.Wrap(err, "models: unable to scan table statistics")
+		}
+		if n >= 0 {
+			ret[name] = n
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "models: failed to read table statistics")
+	}
+
+	return ret, nil
+}
+
+// Nodes は計画のノードを深さ優先（親が先）で返す
+func (p *QueryPlan) Nodes() []*PlanNode {
+	var nodes []*PlanNode
+	var walk func(n *PlanNode)
+	walk = func(n *PlanNode) {
+		nodes = append(nodes, n)
+		for i := range n.Plans {
+			walk(&n.Plans[i])
+		}
+	}
+	walk(&p.Plan)
+
+	return nodes
+}
+
+// relations は計画で読むテーブルの名前を重複なく返す
+func (p *QueryPlan) relations() []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, n := range p.Nodes() {
+		if n.RelationName != "" && !seen[n.RelationName] {
+			seen[n.RelationName] = true
+			names = append(names, n.RelationName)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// estimatedTableRows は n が読むテーブルの推定行数を返す。
+// 統計が無ければ計画の行数（ANALYZE なら実際に読んだ行数）で代える。
+func (p *QueryPlan) estimatedTableRows(n *PlanNode) float64 {
+	rows := n.PlanRows
+	if scanned := n.ActualRows*n.ActualLoops + n.RowsRemovedByFilter; scanned > rows {
+		rows = scanned
+	}
+	if stats, ok := p.TableRows[n.RelationName]; ok && stats > rows {
+		rows = stats
+	}
+
+	return rows
+}
+
+// SeqScansOver は推定 rows 行以上のテーブルを順に読む（Seq Scan）ノードを返す
+func (p *QueryPlan) SeqScansOver(rows float64) []*PlanNode {
+	var ret []*PlanNode
+	for _, n := range p.Nodes() {
+		if n.NodeType == "Seq Scan" && p.estimatedTableRows(n) >= rows {
+			ret = append(ret, n)
+		}
+	}
+
+	return ret
+}
+
+// LargeSeqScans は LargeTableRows 行以上のテーブルの Seq Scan を返す。
+// テストでインデックスが使われていることを確かめるのに使う。
+func (p *QueryPlan) LargeSeqScans() []*PlanNode {
+	return p.SeqScansOver(LargeTableRows)
+}
+
+// UsesIndex は計画が index を使うかを返す
+func (p *QueryPlan) UsesIndex(index string) bool {
+	for _, n := range p.Nodes() {
+		if n.IndexName == index {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String は計画をノードごとに 1 行の木にする。大きいテーブルの Seq Scan には印を付ける。
+func (p *QueryPlan) String() string {
+	var b strings.Builder
+	var walk func(n *PlanNode, depth int)
+	walk = func(n *PlanNode, depth int) {
+		b.WriteString(strings.Repeat("  ", depth))
+		b.WriteString(n.NodeType)
+		if n.IndexName != "" {
+			fmt.Fprintf(&b, " using %s", n.IndexName)
+		}
+		if n.RelationName != "" {
+			fmt.Fprintf(&b, " on %s", n.RelationName)
+			if n.Alias != "" && n.Alias != n.RelationName {
+				fmt.Fprintf(&b, " %s", n.Alias)
+			}
+		}
+		fmt.Fprintf(&b, " (cost=%.2f..%.2f rows=%.0f)", n.StartupCost, n.TotalCost, n.PlanRows)
+		if n.ActualLoops != 0 {
+			fmt.Fprintf(&b, " (actual time=%.3f..%.3f rows=%.0f loops=%.0f)", n.ActualStartupTime, n.ActualTotalTime, n.ActualRows, n.ActualLoops)
+		}
+		if n.NodeType == "Seq Scan" && p.estimatedTableRows(n) >= LargeTableRows {
+			fmt.Fprintf(&b, " [seq scan on a large table: ~%.0f rows]", p.estimatedTableRows(n))
+		}
+		b.WriteByte('\n')
+		for i := range n.Plans {
+			walk(&n.Plans[i], depth+1)
+		}
+	}
+	walk(&p.Plan, 0)
+
+	return b.String()
+}
+
+// Explain は q の実行計画を返す。analyze が true ならクエリを実際に実行する。
+func (q authorQuery) Explain(ctx context.Context, exec boil.ContextExecutor, analyze bool) (*QueryPlan, error) {
+	return explainQuery(ctx, exec, q.Query, analyze)
+}
+
+// Explain は q の実行計画を返す。analyze が true ならクエリを実際に実行する。
+func (q bookQuery) Explain(ctx context.Context, exec boil.ContextExecutor, analyze bool) (*QueryPlan, error) {
+	return explainQuery(ctx, exec, q.Query, analyze)
+}
+
+// Explain は q の実行計画を返す。analyze が true ならクエリを実際に実行する。
+func (q movieQuery) Explain(ctx context.Context, exec boil.ContextExecutor, analyze bool) (*QueryPlan, error) {
+	return explainQuery(ctx, exec, q.Query, analyze)
+}
+
+// Explain は q の実行計画を返す。analyze が true ならクエリを実際に実行する。
+func (q outboxEventQuery) Explain(ctx context.Context, exec boil.ContextExecutor, analyze bool) (*QueryPlan, error) {
+	return explainQuery(ctx, exec, q.Query, analyze)
+}
+
+// Explain は q の実行計画を返す。analyze が true ならクエリを実際に実行する。
+func (q userFavoriteMovieQuery) Explain(ctx context.Context, exec boil.ContextExecutor, analyze bool) (*QueryPlan, error) {
+	return explainQuery(ctx, exec, q.Query, analyze)
+}
+
+// Explain は q の実行計画を返す。analyze が true ならクエリを実際に実行する。
+func (q userQuery) Explain(ctx context.Context, exec boil.ContextExecutor, analyze bool) (*QueryPlan, error) {
+	return explainQuery(ctx, exec, q.Query, analyze)
+}
+
+// Explain は q の実行計画を返す。analyze が true ならクエリを実際に実行する。
+func (q favoriteMovieQuery) Explain(ctx context.Context, exec boil.ContextExecutor, analyze bool) (*QueryPlan, error) {
+	return explainQuery(ctx, exec, q.Query, analyze)
+}
+
+// Explain は q の実行計画を返す。analyze が true ならクエリを実際に実行する。
+func (q movieFavoriteCountQuery) Explain(ctx context.Context, exec boil.ContextExecutor, analyze bool) (*QueryPlan, error) {
+	return explainQuery(ctx, exec, q.Query, analyze)
+}
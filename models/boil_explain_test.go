@@ -0,0 +1,109 @@
+package models
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// searchBooksPlan は SearchBooks の EXPLAIN (ANALYZE, FORMAT JSON) の出力の例。
+// books は Seq Scan、authors は主キーのインデックスで読んでいる。
+const searchBooksPlan = `[{
+  "Plan": {
+    "Node Type": "Limit", "Startup Cost": 412.5, "Total Cost": 412.51, "Plan Rows": 5, "Plan Width": 60,
+    "Actual Startup Time": 3.1, "Actual Total Time": 3.2, "Actual Rows": 5, "Actual Loops": 1,
+    "Plans": [{
+      "Node Type": "Sort", "Startup Cost": 412.5, "Total Cost": 412.6, "Plan Rows": 40, "Plan Width": 60,
+      "Actual Startup Time": 3.1, "Actual Total Time": 3.1, "Actual Rows": 5, "Actual Loops": 1,
+      "Sort Key": ["b.title"],
+      "Plans": [{
+        "Node Type": "Nested Loop", "Join Type": "Left", "Startup Cost": 0.29, "Total Cost": 411.4, "Plan Rows": 40, "Plan Width": 60,
+        "Actual Startup Time": 0.1, "Actual Total Time": 3.0, "Actual Rows": 38, "Actual Loops": 1,
+        "Plans": [
+          {"Node Type": "Seq Scan", "Relation Name": "books", "Alias": "b", "Startup Cost": 0, "Total Cost": 400, "Plan Rows": 40, "Plan Width": 40,
+           "Actual Startup Time": 0.05, "Actual Total Time": 2.9, "Actual Rows": 38, "Actual Loops": 1,
+           "Filter": "((title)::text ~~ '%Go%'::text)", "Rows Removed by Filter": 4962},
+          {"Node Type": "Index Scan", "Relation Name": "authors", "Alias": "a", "Index Name": "authors_pkey", "Startup Cost": 0.29, "Total Cost": 0.3, "Plan Rows": 1, "Plan Width": 20,
+           "Actual Startup Time": 0.001, "Actual Total Time": 0.001, "Actual Rows": 1, "Actual Loops": 38,
+           "Index Cond": "(id = b.author_id)"}
+        ]
+      }]
+    }]
+  },
+  "Planning Time": 0.4,
+  "Execution Time": 3.3
+}]`
+
+func TestParseQueryPlan(t *testing.T) {
+	t.Parallel()
+
+	plan, err := parseQueryPlan([]byte(searchBooksPlan))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plan.Plan.NodeType != "Limit" || plan.ExecutionTime != 3.3 || len(plan.Nodes()) != 5 {
+		t.Errorf("plan = %+v", plan)
+	}
+	if got := plan.relations(); len(got) != 2 || got[0] != "authors" || got[1] != "books" {
+		t.Errorf("relations = %v", got)
+	}
+	if !plan.UsesIndex("authors_pkey") || plan.UsesIndex("books_pkey") {
+		t.Error("UsesIndex")
+	}
+
+	// 統計が無くても、ANALYZE で読んだ行数（38 + 4962）から大きいテーブルかを判断する
+	if scans := plan.SeqScansOver(1000); len(scans) != 1 || scans[0].RelationName != "books" {
+		t.Errorf("SeqScansOver(1000) = %v", scans)
+	}
+	if scans := plan.LargeSeqScans(); len(scans) != 0 {
+		t.Errorf("LargeSeqScans without stats = %v", scans)
+	}
+	plan.TableRows = map[string]float64{"books": 50000, "authors": 800}
+	if scans := plan.LargeSeqScans(); len(scans) != 1 {
+		t.Errorf("LargeSeqScans with stats = %v", scans)
+	}
+
+	want := "      Seq Scan on books b (cost=0.00..400.00 rows=40) (actual time=0.050..2.900 rows=38 loops=1) [seq scan on a large table: ~50000 rows]\n"
+	if s := plan.String(); !strings.Contains(s, want) || !strings.Contains(s, "Index Scan using authors_pkey on authors a") {
+		t.Errorf("String() =\n%s", s)
+	}
+
+	if _, err := parseQueryPlan([]byte(`[]`)); err == nil {
+		t.Error("empty plan: want an error")
+	}
+}
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	insertBooks(t, ctx, tx, 3)
+
+	plan, err := SearchBooks("a", 5).Explain(ctx, tx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(plan.Query, BooksWithAuthorView) || len(plan.Args) != 2 {
+		t.Errorf("query = %s, args = %v", plan.Query, plan.Args)
+	}
+	if plan.Plan.NodeType != "Limit" || plan.Plan.ActualLoops == 0 {
+		t.Errorf("plan =\n%s", plan)
+	}
+	if rels := plan.relations(); len(rels) == 0 || rels[len(rels)-1] != "books" {
+		t.Errorf("relations = %v", rels)
+	}
+
+	plan, err = Books(BookWhere.ID.EQ(1)).Explain(ctx, tx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.ExecutionTime != 0 || plan.Plan.ActualLoops != 0 {
+		t.Errorf("EXPLAIN without ANALYZE must not run the query:\n%s", plan)
+	}
+}
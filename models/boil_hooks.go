@@ -0,0 +1,201 @@
+package models
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// フックの登録と context ごとのフック。
+//
+// 生成コードの AddXHook はパッケージ全体のスライスに足すだけで、外すことも順序を付けることもできない。
+// RegisterXHook で登録したフックは名前と順序を持ち、返した関数で外せる。
+// WithHooks で context に載せたフックは、その context を渡した操作でだけ、登録済みのフックに加えて実行する。
+//
+// どちらも生成コードの doXHooks が AddXHook で足したフックの後に実行する。
+// テーブルごとの登録先と RegisterXHook・XContextHook は templates/main/02_hooks.go.tpl から生成する。
+//
+// 一括操作（クエリやスライスの UpdateAll / DeleteAll）のフックは RegisterXBulkHook・XBulkContextHook で登録し、
+// 操作の前後に XBulkOp を受け取る。登録や context への載せ方、名前を指定した SkipHooks は行ごとのフックと同じ。
+
+// BulkHookPoint は一括操作のフックを実行する時点
+type BulkHookPoint int
+
+// 一括操作のフックを実行する時点
+const (
+	BeforeUpdateAllHook BulkHookPoint = iota + 1
+	AfterUpdateAllHook
+	BeforeDeleteAllHook
+	AfterDeleteAllHook
+)
+
+// HookOption はフックの名前や順序を指定する
+type HookOption func(*hookOptions)
+
+type hookOptions struct {
+	name  string
+	order int
+}
+
+// HookName はフックに名前を付ける。同じ時点に同じ名前のフックを登録すると前のものと置き換わる。
+// SkipHooks で名前を指定して実行しないようにもできる。
+func HookName(name string) HookOption {
+	return func(o *hookOptions) { o.name = name }
+}
+
+// HookOrder はフックを実行する順序を指定する。小さいものから実行し、同じなら登録した順に実行する。省略すると 0。
+func HookOrder(order int) HookOption {
+	return func(o *hookOptions) { o.order = order }
+}
+
+// hookSeq は登録済みのフックと context のフックで共有する登録順
+var hookSeq atomic.Uint64
+
+type registeredHook[O any] struct {
+	seq   uint64
+	name  string
+	order int
+	fn    func(context.Context, boil.ContextExecutor, O) error
+}
+
+func newRegisteredHook[O any](fn func(context.Context, boil.ContextExecutor, O) error, opts []HookOption) registeredHook[O] {
+	var o hookOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return registeredHook[O]{seq: hookSeq.Add(1), name: o.name, order: o.order, fn: fn}
+}
+
+// hookRegistry は 1 テーブル分の登録済みフック
+// P はフックの時点の型（boil.HookPoint や BulkHookPoint）、O はフックに渡すもの。
+type hookRegistry[P comparable, O any] struct {
+	table string
+
+	mu    sync.Mutex
+	hooks map[P][]registeredHook[O]
+}
+
+// register は hook を登録し、登録を外す関数を返す。返した関数は何度呼んでもよい。
+func (r *hookRegistry[P, O]) register(hookPoint P, fn func(context.Context, boil.ContextExecutor, O) error, opts []HookOption) func() {
+	h := newRegisteredHook(fn, opts)
+
+	r.mu.Lock()
+	if r.hooks == nil {
+		r.hooks = map[P][]registeredHook[O]{}
+	}
+	hooks := r.hooks[hookPoint]
+	if h.name != "" {
+		hooks = removeHooks(hooks, func(x registeredHook[O]) bool { return x.name == h.name })
+	}
+	r.hooks[hookPoint] = append(hooks, h)
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		r.hooks[hookPoint] = removeHooks(r.hooks[hookPoint], func(x registeredHook[O]) bool { return x.seq == h.seq })
+		r.mu.Unlock()
+	}
+}
+
+// removeHooks は match に当てはまるフックを除いたスライスを新しく作って返す
+func removeHooks[O any](hooks []registeredHook[O], match func(registeredHook[O]) bool) []registeredHook[O] {
+	kept := make([]registeredHook[O], 0, len(hooks))
+	for _, h := range hooks {
+		if !match(h) {
+			kept = append(kept, h)
+		}
+	}
+
+	return kept
+}
+
+// contextHook は hook を context に載せられる ContextHook にする
+func (r *hookRegistry[P, O]) contextHook(hookPoint P, fn func(context.Context, boil.ContextExecutor, O) error, opts []HookOption) ContextHook {
+	return ContextHook{table: r.table, point: hookPoint, hook: newRegisteredHook(fn, opts)}
+}
+
+// run は登録済みのフックと ctx のフックを順序どおりに実行する
+func (r *hookRegistry[P, O]) run(ctx context.Context, exec boil.ContextExecutor, hookPoint P, o O) error {
+	r.mu.Lock()
+	hooks := append([]registeredHook[O](nil), r.hooks[hookPoint]...)
+	r.mu.Unlock()
+
+	for _, ch := range contextHooksFrom(ctx) {
+		if ch.table == r.table && ch.point == hookPoint {
+			hooks = append(hooks, ch.hook.(registeredHook[O]))
+		}
+	}
+
+	sort.SliceStable(hooks, func(i, j int) bool {
+		if hooks[i].order != hooks[j].order {
+			return hooks[i].order < hooks[j].order
+		}
+		return hooks[i].seq < hooks[j].seq
+	})
+
+	skipped := skippedHookNames(ctx)
+	for _, h := range hooks {
+		if h.name != "" && skipped[h.name] {
+			continue
+		}
+		if err := h.fn(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextHook は WithHooks で context に載せるフック。BookContextHook や BookBulkContextHook などで作る。
+type ContextHook struct {
+	table string
+	point interface{}
+	hook  interface{}
+}
+
+type contextHooksKey struct{}
+
+// WithHooks は hooks を載せた context を返す。ctx にすでに載っているフックも引き継ぐ。
+// 載せたフックはその context を渡した操作でだけ、登録済みのフックに加えて実行する。
+func WithHooks(ctx context.Context, hooks ...ContextHook) context.Context {
+	prev := contextHooksFrom(ctx)
+	all := make([]ContextHook, 0, len(prev)+len(hooks))
+	all = append(append(all, prev...), hooks...)
+
+	return context.WithValue(ctx, contextHooksKey{}, all)
+}
+
+func contextHooksFrom(ctx context.Context) []ContextHook {
+	hooks, _ := ctx.Value(contextHooksKey{}).([]ContextHook)
+	return hooks
+}
+
+type skipHooksKey struct{}
+
+// SkipHooks はフックを実行しない context を返す。メンテナンス用のスクリプトなどで使う。
+// names を省略すると boil.SkipHooks と同じくすべてのフックを、指定するとその名前のフックだけを実行しない。
+func SkipHooks(ctx context.Context, names ...string) context.Context {
+	if len(names) == 0 {
+		return boil.SkipHooks(ctx)
+	}
+
+	prev := skippedHookNames(ctx)
+	skipped := make(map[string]bool, len(prev)+len(names))
+	for name := range prev {
+		skipped[name] = true
+	}
+	for _, name := range names {
+		skipped[name] = true
+	}
+
+	return context.WithValue(ctx, skipHooksKey{}, skipped)
+}
+
+func skippedHookNames(ctx context.Context) map[string]bool {
+	skipped, _ := ctx.Value(skipHooksKey{}).(map[string]bool)
+	return skipped
+}
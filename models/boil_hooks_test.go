@@ -0,0 +1,136 @@
+package models
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// recordHook は呼ばれたことを calls に記録する Book のフックを返す
+func recordHook(calls *[]string, name string) BookHook {
+	return func(context.Context, boil.ContextExecutor, *Book) error {
+		*calls = append(*calls, name)
+		return nil
+	}
+}
+
+// newTestBookHooks は生成コードのフックを使わない、テスト用の Book のフックの登録先を作る
+func newTestBookHooks() *hookRegistry[boil.HookPoint, *Book] {
+	return &hookRegistry[boil.HookPoint, *Book]{table: "test_books"}
+}
+
+func TestHookRegistryOrderAndUnregister(t *testing.T) {
+	t.Parallel()
+
+	r := newTestBookHooks()
+	var calls []string
+
+	r.register(boil.BeforeInsertHook, recordHook(&calls, "a"), nil)
+	unregisterB := r.register(boil.BeforeInsertHook, recordHook(&calls, "b"), []HookOption{HookOrder(-1)})
+	r.register(boil.BeforeInsertHook, recordHook(&calls, "c"), []HookOption{HookName("c")})
+	r.register(boil.BeforeInsertHook, recordHook(&calls, "c2"), []HookOption{HookName("c")})
+	r.register(boil.AfterInsertHook, recordHook(&calls, "after"), nil)
+
+	ctx := context.Background()
+	if err := r.run(ctx, nil, boil.BeforeInsertHook, &Book{}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"b", "a", "c2"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+
+	calls = nil
+	unregisterB()
+	unregisterB()
+	if err := r.run(ctx, nil, boil.BeforeInsertHook, &Book{}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "c2"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestHookRegistryContextHooks(t *testing.T) {
+	t.Parallel()
+
+	r := newTestBookHooks()
+	other := newTestBookHooks()
+	other.table = "other"
+	var calls []string
+
+	r.register(boil.BeforeUpdateHook, recordHook(&calls, "global"), []HookOption{HookName("global")})
+
+	ctx := WithHooks(context.Background(), r.contextHook(boil.BeforeUpdateHook, recordHook(&calls, "first"), nil))
+	ctx = WithHooks(ctx,
+		r.contextHook(boil.BeforeUpdateHook, recordHook(&calls, "early"), []HookOption{HookOrder(-1)}),
+		r.contextHook(boil.AfterUpdateHook, recordHook(&calls, "after"), nil),
+		other.contextHook(boil.BeforeUpdateHook, recordHook(&calls, "other table"), nil),
+	)
+
+	if err := r.run(ctx, nil, boil.BeforeUpdateHook, &Book{}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"early", "global", "first"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+
+	calls = nil
+	if err := r.run(context.Background(), nil, boil.BeforeUpdateHook, &Book{}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"global"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("context hooks should not leak: calls = %v", calls)
+	}
+
+	calls = nil
+	if err := r.run(SkipHooks(ctx, "global"), nil, boil.BeforeUpdateHook, &Book{}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"early", "first"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestSkipHooks(t *testing.T) {
+	t.Parallel()
+
+	ctx := SkipHooks(SkipHooks(context.Background(), "a"), "b")
+	if skipped := skippedHookNames(ctx); !skipped["a"] || !skipped["b"] || boil.HooksAreSkipped(ctx) {
+		t.Errorf("skipped = %v", skipped)
+	}
+	if !boil.HooksAreSkipped(SkipHooks(ctx)) {
+		t.Error("SkipHooks without names should skip all hooks")
+	}
+}
+
+func TestRegisterBookHook(t *testing.T) {
+	t.Parallel()
+
+	// 登録したフックはパッケージ全体で実行されるので、このテストの Book でだけ記録する
+	o := &Book{}
+	var calls []string
+	record := func(name string) BookHook {
+		return func(_ context.Context, _ boil.ContextExecutor, b *Book) error {
+			if b == o {
+				calls = append(calls, name)
+			}
+			return nil
+		}
+	}
+
+	unregister := RegisterBookHook(boil.BeforeUpsertHook, record("global"))
+	t.Cleanup(unregister)
+
+	ctx := WithHooks(context.Background(), BookContextHook(boil.BeforeUpsertHook, record("scoped")))
+	if err := o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.doBeforeUpsertHooks(boil.SkipHooks(ctx), nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"global", "scoped"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
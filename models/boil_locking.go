@@ -0,0 +1,85 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+// ErrLockOutsideTx はトランザクション外で行ロック付きのクエリを実行しようとしたときに返る。
+// PostgreSQL ではトランザクション外の FOR UPDATE は文の終了と同時にロックが外れ、意味を持たない。
+var ErrLockOutsideTx = errors.New("models: row locking clause requires a transaction executor")
+
+// LockMod は SELECT に付ける行ロック句（FOR UPDATE / FOR SHARE など）を表すクエリ修飾子。
+// トランザクションかどうかを確認するのは AllLocked・OneLocked・FindBookLocked などの *Locked だけで、
+// 普通のクエリ修飾子として渡したときは確認しない。
+type LockMod struct {
+	strength string
+	wait     string
+	of       []string
+}
+
+// ForUpdate は FOR UPDATE を付ける
+func ForUpdate() LockMod { return LockMod{strength: "UPDATE"} }
+
+// ForNoKeyUpdate は FOR NO KEY UPDATE を付ける
+func ForNoKeyUpdate() LockMod { return LockMod{strength: "NO KEY UPDATE"} }
+
+// ForShare は FOR SHARE を付ける
+func ForShare() LockMod { return LockMod{strength: "SHARE"} }
+
+// ForKeyShare は FOR KEY SHARE を付ける
+func ForKeyShare() LockMod { return LockMod{strength: "KEY SHARE"} }
+
+// SkipLocked は他のトランザクションがロック中の行を読み飛ばす
+func (m LockMod) SkipLocked() LockMod {
+	m.wait = "SKIP LOCKED"
+	return m
+}
+
+// NoWait はロックを待たずにエラー（lock_not_available）にする
+func (m LockMod) NoWait() LockMod {
+	m.wait = "NOWAIT"
+	return m
+}
+
+// Of はロック対象のテーブルを限定する（JOIN したクエリ向け）
+func (m LockMod) Of(tables ...string) LockMod {
+	m.of = append(append([]string{}, m.of...), tables...)
+	return m
+}
+
+// Apply implements qm.QueryMod.Apply.
+func (m LockMod) Apply(q *queries.Query) {
+	queries.SetFor(q, m.clause())
+}
+
+// clause は FOR の後ろに続く句を返す
+func (m LockMod) clause() string {
+	strength := m.strength
+	if len(strength) == 0 {
+		strength = "UPDATE"
+	}
+
+	parts := []string{strength}
+	if len(m.of) != 0 {
+		parts = append(parts, "OF "+strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, m.of), ", "))
+	}
+	if len(m.wait) != 0 {
+		parts = append(parts, m.wait)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// checkLockExecutor は exec がトランザクションかどうかを確認する
+func checkLockExecutor(exec boil.ContextExecutor) error {
+	if _, ok := exec.(boil.ContextTransactor); !ok {
+		return ErrLockOutsideTx
+	}
+
+	return nil
+}
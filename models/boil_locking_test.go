@@ -0,0 +1,67 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+func TestLockModClause(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		mod  LockMod
+		want string
+	}{
+		{ForUpdate(), "FOR UPDATE;"},
+		{ForNoKeyUpdate(), "FOR NO KEY UPDATE;"},
+		{ForShare(), "FOR SHARE;"},
+		{ForKeyShare(), "FOR KEY SHARE;"},
+		{ForUpdate().SkipLocked(), "FOR UPDATE SKIP LOCKED;"},
+		{ForShare().NoWait(), "FOR SHARE NOWAIT;"},
+		{ForUpdate().Of("books").SkipLocked(), `FOR UPDATE OF "books" SKIP LOCKED;`},
+	}
+
+	for _, test := range tests {
+		sql, _ := queries.BuildQuery(Books(qm.Limit(1), test.mod).Query)
+		if !strings.HasSuffix(sql, test.want) {
+			t.Errorf("want suffix %q, got %q", test.want, sql)
+		}
+	}
+}
+
+func TestLockedCopiesQuery(t *testing.T) {
+	t.Parallel()
+
+	q := Books(qm.Limit(1))
+	locked, _ := queries.BuildQuery(q.locked(ForUpdate()).Query)
+	if !strings.HasSuffix(locked, "FOR UPDATE;") {
+		t.Errorf("want FOR UPDATE, got %q", locked)
+	}
+
+	if sql, _ := queries.BuildQuery(q.Query); strings.Contains(sql, "FOR UPDATE") {
+		t.Errorf("original query was modified: %q", sql)
+	}
+}
+
+func TestLockOutsideTx(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := boil.GetContextDB()
+
+	if _, err := FindBookForUpdate(ctx, db, 1); !errors.Is(err, ErrLockOutsideTx) {
+		t.Errorf("FindBookForUpdate: want ErrLockOutsideTx, got %v", err)
+	}
+	if _, err := Books().AllLocked(ctx, db, ForUpdate()); !errors.Is(err, ErrLockOutsideTx) {
+		t.Errorf("AllLocked: want ErrLockOutsideTx, got %v", err)
+	}
+	if _, err := Books().OneLocked(ctx, db, ForShare()); !errors.Is(err, ErrLockOutsideTx) {
+		t.Errorf("OneLocked: want ErrLockOutsideTx, got %v", err)
+	}
+}
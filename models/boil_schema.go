@@ -0,0 +1,85 @@
+package models
+
+import (
+	"reflect"
+)
+
+// TableSchema はモデル生成時に前提としたテーブル定義
+type TableSchema struct {
+	Name               string
+	Columns            []string
+	ColumnsWithDefault []string
+	PrimaryKey         []string
+	// Type はモデルの構造体の型。boil タグからカラムごとの Go の型を引く
+	Type reflect.Type
+}
+
+// TableSchemas は生成済みモデルのテーブル定義の一覧。
+// モデルを再生成してテーブルを追加したらここにも追加する（schema check が漏れを検出する）。
+var TableSchemas = []TableSchema{
+	{
+		Name:               TableNames.Authors,
+		Columns:            authorAllColumns,
+		ColumnsWithDefault: authorColumnsWithDefault,
+		PrimaryKey:         authorPrimaryKeyColumns,
+		Type:               authorType.Elem(),
+	},
+	{
+		Name:               TableNames.Books,
+		Columns:            bookAllColumns,
+		ColumnsWithDefault: bookColumnsWithDefault,
+		PrimaryKey:         bookPrimaryKeyColumns,
+		Type:               bookType.Elem(),
+	},
+	{
+		Name:               TableNames.Movies,
+		Columns:            movieAllColumns,
+		ColumnsWithDefault: movieColumnsWithDefault,
+		PrimaryKey:         moviePrimaryKeyColumns,
+		Type:               movieType.Elem(),
+	},
+	{
+		Name:               TableNames.OutboxEvents,
+		Columns:            outboxEventAllColumns,
+		ColumnsWithDefault: outboxEventColumnsWithDefault,
+		PrimaryKey:         outboxEventPrimaryKeyColumns,
+		Type:               outboxEventType.Elem(),
+	},
+	{
+		Name:               TableNames.UserFavoriteMovies,
+		Columns:            userFavoriteMovieAllColumns,
+		ColumnsWithDefault: userFavoriteMovieColumnsWithDefault,
+		PrimaryKey:         userFavoriteMoviePrimaryKeyColumns,
+		Type:               userFavoriteMovieType.Elem(),
+	},
+	{
+		Name:               TableNames.Users,
+		Columns:            userAllColumns,
+		ColumnsWithDefault: userColumnsWithDefault,
+		PrimaryKey:         userPrimaryKeyColumns,
+		Type:               userType.Elem(),
+	},
+}
+
+// ColumnType はカラムに対応する構造体フィールドの Go の型名（例: "null.Int"）を返す
+func (t TableSchema) ColumnType(column string) (string, bool) {
+	for i := 0; i < t.Type.NumField(); i++ {
+		f := t.Type.Field(i)
+		if f.Tag.Get("boil") == column {
+			return f.Type.String(), true
+		}
+	}
+
+	return "", false
+}
+
+// TableNameList は TableNames に含まれる全テーブル名を返す
+func TableNameList() []string {
+	v := reflect.ValueOf(TableNames)
+	names := make([]string, v.NumField())
+	for i := range names {
+		names[i] = v.Field(i).String()
+	}
+
+	return names
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/volatiletech/null/v8"
+)
+
+// 入力値の検証。GraphQL・gRPC・CLI で同じ規則を使う。
+// エラーは *ValidationError で返すので、呼び出し側でそれぞれの形（GraphQL の extensions や gRPC の InvalidArgument）にする。
+
+// MaxTextLength は文字列のカラムの最大長（VARCHAR(255)）
+const MaxTextLength = 255
+
+// ValidationError は入力値の検証エラー。Field は呼び出し側が渡した入力のフィールド名。
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("models: %s %s", e.Field, e.Message)
+}
+
+// ValidateText は必須の文字列を前後の空白を除いて検証し、除いた値を返す
+func ValidateText(field, s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if len(s) == 0 {
+		return "", &ValidationError{Field: field, Message: "must not be empty"}
+	}
+	if utf8.RuneCountInString(s) > MaxTextLength {
+		return "", &ValidationError{Field: field, Message: fmt.Sprintf("must be at most %d characters", MaxTextLength)}
+	}
+
+	return s, nil
+}
+
+// ValidateYear は出版年が 1 年から来年までにあるか検証する。NULL はそのまま通す。
+func ValidateYear(field string, year null.Int) error {
+	if !year.Valid {
+		return nil
+	}
+
+	latest := time.Now().Year() + 1
+	if year.Int < 1 || year.Int > latest {
+		return &ValidationError{Field: field, Message: fmt.Sprintf("must be between 1 and %d", latest)}
+	}
+
+	return nil
+}
+
+// likeEscaper は LIKE のパターンで特別な意味を持つ文字をエスケープする（PostgreSQL の既定のエスケープ文字は \）
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ContainsPattern は s を含む文字列に一致する LIKE / ILIKE のパターンを返す。s の % や _ は文字どおりに扱う。
+func ContainsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestValidateText(t *testing.T) {
+	t.Parallel()
+
+	if s, err := ValidateText("title", "  Solaris "); err != nil || s != "Solaris" {
+		t.Errorf("got %q, %v", s, err)
+	}
+	// 長さはバイト数ではなく文字数で数える
+	if _, err := ValidateText("title", strings.Repeat("ł", MaxTextLength)); err != nil {
+		t.Error(err)
+	}
+
+	for _, bad := range []string{" \t", strings.Repeat("x", MaxTextLength+1)} {
+		_, err := ValidateText("title", bad)
+		var ve *ValidationError
+		if !errors.As(err, &ve) || ve.Field != "title" {
+			t.Errorf("%q: got %v", bad, err)
+		}
+	}
+}
+
+func TestValidateYear(t *testing.T) {
+	t.Parallel()
+
+	for _, ok := range []null.Int{{}, null.IntFrom(1), null.IntFrom(1961)} {
+		if err := ValidateYear("year", ok); err != nil {
+			t.Errorf("%v: %v", ok, err)
+		}
+	}
+	for _, bad := range []null.Int{null.IntFrom(0), null.IntFrom(-5), null.IntFrom(9999)} {
+		if err := ValidateYear("year", bad); err == nil {
+			t.Errorf("%v: want an error", bad)
+		}
+	}
+}
+
+func TestContainsPattern(t *testing.T) {
+	t.Parallel()
+
+	if got := ContainsPattern(`100%_\`); got != `%100\%\_\\%` {
+		t.Errorf("got %s", got)
+	}
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// BooksWithAuthorView は books.author から authors への移行期間中の互換ビュー
+// （000009_create_authors_table）。books と同じ列を持ち、author は authors の名前になる。
+const BooksWithAuthorView = "books_with_author"
+
+// BooksWithAuthor は互換ビューを books という別名で読むクエリ。
+// BookWhere や "books"."author" を使う既存の条件がそのまま使える。ビューなので読み出し専用。
+func BooksWithAuthor(mods ...qm.QueryMod) bookQuery {
+	mods = append(mods, qm.From(fmt.Sprintf("%q as %q", BooksWithAuthorView, TableNames.Books)))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"books\".*"})
+	}
+
+	return bookQuery{q}
+}
+
+// FindOrCreateAuthor は表記ゆれを同一視して（normalize_author_name）著者を探し、無ければ作る。
+// 著者は接続中のテナントに作られる。既存の著者の表記は変えない。
+// 既存の著者は One と同じく読み込み、BeforeInsert / AfterInsert フックは実際に INSERT したときだけ実行する。
+// 同じ著者を同時に作ろうとして先を越された場合は、BeforeInsert フックだけが実行され、先に作られた著者を返す。
+func FindOrCreateAuthor(ctx context.Context, exec boil.ContextExecutor, name string) (*Author, error) {
+	o, err := findAuthorByName(ctx, exec, name)
+	if err == nil {
+		return o, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Wrap(err, "models: unable to find author")
+	}
+
+	o = &Author{Name: name}
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return nil, err
+	}
+
+	// 競合したときは行が返らないので、探し直して先に作られた著者を返す
+	query := "INSERT INTO \"authors\" (\"name\") VALUES ($1) " +
+		"ON CONFLICT (\"tenant_id\", normalize_author_name(\"name\")) DO NOTHING " +
+		"RETURNING \"id\",\"name\",\"created_at\",\"tenant_id\""
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, o.Name)
+	}
+	err = exec.QueryRowContext(ctx, query, o.Name).Scan(&o.ID, &o.Name, &o.CreatedAt, &o.TenantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		o, err = findAuthorByName(ctx, exec, name)
+		if err != nil {
+			return nil, errors.Wrap(err, "models: unable to find author after a conflicting insert")
+		}
+		return o, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "models: unable to find or create author")
+	}
+
+	return o, o.doAfterInsertHooks(ctx, exec)
+}
+
+// findAuthorByName は接続中のテナントで name と同じ名前の著者を探す。
+// テナントの条件は authors.tenant_id のデフォルトと同じなので、RLS を素通りするロールでも
+// INSERT の ON CONFLICT と同じ行が見つかる。
+func findAuthorByName(ctx context.Context, exec boil.ContextExecutor, name string) (*Author, error) {
+	return Authors(
+		qm.Where("\"authors\".\"tenant_id\" = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), '0')::integer"),
+		qm.Where("normalize_author_name(\"authors\".\"name\") = normalize_author_name(?)", name),
+	).One(ctx, exec)
+}
+
+// AssignAuthor は name の著者を FindOrCreateAuthor で探すか作り、o の author・author_id と R.Author に入れる。
+// o 自体は保存しないので、Update するときは author と author_id の両方を書く。
+// 保存に失敗したときに著者だけが残らないよう、保存と同じトランザクションで呼ぶ。
+func (o *Book) AssignAuthor(ctx context.Context, exec boil.ContextExecutor, name string) error {
+	a, err := FindOrCreateAuthor(ctx, exec, name)
+	if err != nil {
+		return err
+	}
+
+	o.AuthorName = name
+	o.AuthorID = null.IntFrom(a.ID)
+	if o.R == nil {
+		o.R = &bookR{}
+	}
+	o.R.Author = a
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+func TestBooksWithAuthorQuery(t *testing.T) {
+	t.Parallel()
+
+	q := BooksWithAuthor(qm.Where("title LIKE ? OR author LIKE ?", "%Go%", "%Go%"), qm.Limit(5))
+
+	sql, args := queries.BuildQuery(q.Query)
+	want := `SELECT "books".* FROM "books_with_author" as "books" WHERE (title LIKE $1 OR author LIKE $2) LIMIT 5;`
+	if sql != want {
+		t.Errorf("got:\n%s\nwant:\n%s", sql, want)
+	}
+	if len(args) != 2 {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestFindOrCreateAuthorDeduplicates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	a, err := FindOrCreateAuthor(ctx, tx, "Ursula K. Le Guin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := FindOrCreateAuthor(ctx, tx, "  ursula k.  le guin ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.ID != b.ID {
+		t.Errorf("want the same author, got %d and %d", a.ID, b.ID)
+	}
+	if b.Name != "Ursula K. Le Guin" {
+		t.Errorf("existing spelling should be kept, got %q", b.Name)
+	}
+}
+
+func TestFindOrCreateAuthorInsertHooks(t *testing.T) {
+	t.Parallel()
+
+	var before, after int
+	ctx := WithHooks(context.Background(),
+		AuthorContextHook(boil.BeforeInsertHook, func(context.Context, boil.ContextExecutor, *Author) error {
+			before++
+			return nil
+		}),
+		AuthorContextHook(boil.AfterInsertHook, func(context.Context, boil.ContextExecutor, *Author) error {
+			after++
+			return nil
+		}),
+	)
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := FindOrCreateAuthor(ctx, tx, "Stanisław Lem"); err != nil {
+		t.Fatal(err)
+	}
+	// 既存の著者を返すときはフックを実行しない
+	if _, err := FindOrCreateAuthor(ctx, tx, "stanisław lem"); err != nil {
+		t.Fatal(err)
+	}
+	if before != 1 || after != 1 {
+		t.Errorf("insert hooks ran %d/%d times, want once", before, after)
+	}
+}
+
+func TestBooksWithAuthorUsesAuthorName(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	author, err := FindOrCreateAuthor(ctx, tx, "Octavia E. Butler")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	linked := &Book{Title: "Kindred", AuthorName: "octavia butler"}
+	if err := linked.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err := linked.SetAuthor(ctx, tx, false, author); err != nil {
+		t.Fatal(err)
+	}
+
+	legacy := &Book{Title: "Parable of the Sower", AuthorName: "O. E. Butler"}
+	if err := legacy.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	books, err := BooksWithAuthor(BookWhere.ID.IN([]int{linked.ID, legacy.ID}), qm.OrderBy("id")).All(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(books) != 2 {
+		t.Fatalf("want 2 books, got %d", len(books))
+	}
+	if books[0].AuthorName != author.Name {
+		t.Errorf("linked book author = %q, want %q", books[0].AuthorName, author.Name)
+	}
+	if books[1].AuthorName != legacy.AuthorName {
+		t.Errorf("unlinked book should fall back to books.author, got %q", books[1].AuthorName)
+	}
+
+	written, err := author.Books().All(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(written) != 1 || written[0].ID != linked.ID {
+		t.Errorf("author.Books() = %v, want book %d", written, linked.ID)
+	}
+}
+
+func TestBookAssignAuthor(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	o := &Book{Title: "The Dispossessed"}
+	if err := o.AssignAuthor(ctx, tx, "Ursula K. Le Guin"); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	// 表記ゆれのある名前に変えても同じ著者を指す
+	if err := o.AssignAuthor(ctx, tx, "ursula k. le guin"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := o.Update(ctx, tx, boil.Whitelist(BookColumns.AuthorName, BookColumns.AuthorID)); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Books(BookWhere.ID.EQ(o.ID), qm.Load(BookRels.Author)).One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.AuthorID.Valid || loaded.R.Author == nil || loaded.R.Author.Name != "Ursula K. Le Guin" {
+		t.Errorf("author_id = %v, author = %+v", loaded.AuthorID, loaded.R.Author)
+	}
+	if loaded.AuthorName != "ursula k. le guin" {
+		t.Errorf("author = %q", loaded.AuthorName)
+	}
+}
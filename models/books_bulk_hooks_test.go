@@ -0,0 +1,171 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// 一括フックは context に載せて、ほかのテストの一括操作で実行されないようにする
+
+func TestBookBulkHooksVeto(t *testing.T) {
+	t.Parallel()
+
+	errVeto := errors.New("read only")
+	veto := func(context.Context, boil.ContextExecutor, *BookBulkOp) error { return errVeto }
+	ctx := WithHooks(context.Background(),
+		BookBulkContextHook(BeforeUpdateAllHook, veto),
+		BookBulkContextHook(BeforeDeleteAllHook, veto),
+	)
+
+	// 取りやめた操作は exec を使わないので nil でよい
+	slice := BookSlice{{ID: 1}}
+	if _, err := Books().UpdateAll(ctx, nil, M{BookColumns.Title: "x"}); !errors.Is(err, errVeto) {
+		t.Errorf("query update: got %v", err)
+	}
+	if _, err := slice.UpdateAll(ctx, nil, M{BookColumns.Title: "x"}); !errors.Is(err, errVeto) {
+		t.Errorf("slice update: got %v", err)
+	}
+	if _, err := Books().DeleteAll(ctx, nil); !errors.Is(err, errVeto) {
+		t.Errorf("query delete: got %v", err)
+	}
+	if _, err := slice.DeleteAll(ctx, nil); !errors.Is(err, errVeto) {
+		t.Errorf("slice delete: got %v", err)
+	}
+	if _, err := Books().DeleteAllReturning(ctx, nil); !errors.Is(err, errVeto) {
+		t.Errorf("query delete returning: got %v", err)
+	}
+}
+
+func TestRegisterBookBulkHook(t *testing.T) {
+	t.Parallel()
+
+	// 登録したフックはパッケージ全体で実行されるので、このテストの操作でだけ記録する
+	op := &BookBulkOp{Slice: BookSlice{{ID: -1}}}
+	var calls []string
+	record := func(name string) BookBulkHook {
+		return func(_ context.Context, _ boil.ContextExecutor, o *BookBulkOp) error {
+			if o == op {
+				calls = append(calls, name)
+			}
+			return nil
+		}
+	}
+
+	t.Cleanup(RegisterBookBulkHook(BeforeDeleteAllHook, record("audit"), HookName("test.audit")))
+	t.Cleanup(RegisterBookBulkHook(BeforeDeleteAllHook, record("first"), HookOrder(-1)))
+	t.Cleanup(RegisterBookBulkHook(AfterDeleteAllHook, record("after")))
+
+	ctx := WithHooks(context.Background(), BookBulkContextHook(BeforeDeleteAllHook, record("scoped")))
+	if err := doBookBulkHooks(ctx, nil, BeforeDeleteAllHook, op); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"first", "audit", "scoped"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+
+	// Book のフックと同じく、名前を指定した SkipHooks と boil.SkipHooks が効く
+	calls = nil
+	if err := doBookBulkHooks(SkipHooks(ctx, "test.audit"), nil, BeforeDeleteAllHook, op); err != nil {
+		t.Fatal(err)
+	}
+	if err := doBookBulkHooks(boil.SkipHooks(ctx), nil, BeforeDeleteAllHook, op); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"first", "scoped"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestBookBulkHooksUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	var after []*BookBulkOp
+	ctx := WithHooks(context.Background(),
+		BookBulkContextHook(BeforeUpdateAllHook, func(_ context.Context, _ boil.ContextExecutor, op *BookBulkOp) error {
+			op.Cols[BookColumns.AuthorName] = "hooked"
+			return nil
+		}),
+		BookBulkContextHook(AfterUpdateAllHook, func(_ context.Context, _ boil.ContextExecutor, op *BookBulkOp) error {
+			after = append(after, op)
+			return nil
+		}),
+	)
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	books := insertBooks(t, ctx, tx, 2)
+	ids := bookIDs(books)
+
+	rowsAff, err := Books(BookWhere.ID.IN(ids)).UpdateAll(ctx, tx, M{BookColumns.Title: "query"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rowsAff != 2 {
+		t.Errorf("want 2 rows, got %d", rowsAff)
+	}
+	if _, err := books[:1].UpdateAll(ctx, tx, M{BookColumns.Title: "slice"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(after) != 2 {
+		t.Fatalf("want 2 after hooks, got %d", len(after))
+	}
+	if after[0].Query == nil || after[0].RowsAffected != 2 {
+		t.Errorf("query op = %+v", after[0])
+	}
+	if len(after[1].Slice) != 1 || after[1].RowsAffected != 1 {
+		t.Errorf("slice op = %+v", after[1])
+	}
+
+	if err := books[0].Reload(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	if books[0].Title != "slice" || books[0].AuthorName != "hooked" {
+		t.Errorf("got title %q author %q", books[0].Title, books[0].AuthorName)
+	}
+
+	// SkipHooks した context では一括フックも実行しない
+	if _, err := Books(BookWhere.ID.IN(ids)).UpdateAll(boil.SkipHooks(ctx), tx, M{BookColumns.Title: "skipped"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(after) != 2 {
+		t.Errorf("hooks should be skipped, got %d calls", len(after))
+	}
+}
+
+func TestBookBulkHooksDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	var calls []BulkHookPoint
+	record := func(point BulkHookPoint) BookBulkHook {
+		return func(context.Context, boil.ContextExecutor, *BookBulkOp) error {
+			calls = append(calls, point)
+			return nil
+		}
+	}
+	ctx := WithHooks(context.Background(),
+		BookBulkContextHook(BeforeDeleteAllHook, record(BeforeDeleteAllHook)),
+		BookBulkContextHook(AfterDeleteAllHook, record(AfterDeleteAllHook)),
+	)
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	books := insertBooks(t, ctx, tx, 3)
+	ids := bookIDs(books)
+
+	if _, err := books[:1].DeleteAll(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Books(BookWhere.ID.IN(ids)).DeleteAll(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []BulkHookPoint{BeforeDeleteAllHook, AfterDeleteAllHook, BeforeDeleteAllHook, AfterDeleteAllHook}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func TestBookChanged(t *testing.T) {
+	t.Parallel()
+
+	o := &Book{ID: 1, Title: "Go", AuthorName: "Pike", PublishedYear: null.IntFrom(2015)}
+	if o.Changed() != nil {
+		t.Error("untracked book should report no changes")
+	}
+
+	o.Track()
+	if changes := o.Changed(); len(changes) != 0 {
+		t.Errorf("want no changes right after Track, got %v", changes)
+	}
+
+	o.Title = "The Go Programming Language"
+	o.PublishedYear = null.Int{}
+
+	want := []ColumnChange{
+		{Column: BookColumns.Title, Old: "Go", New: "The Go Programming Language"},
+		{Column: BookColumns.PublishedYear, Old: int64(2015), New: nil},
+	}
+	if changes := o.Changed(); !reflect.DeepEqual(changes, want) {
+		t.Errorf("got %#v\nwant %#v", changes, want)
+	}
+
+	snapshot, ok := o.Snapshot()
+	if !ok || snapshot.Title != "Go" {
+		t.Errorf("snapshot = %v, %t", snapshot, ok)
+	}
+}
+
+func TestBookCopy(t *testing.T) {
+	t.Parallel()
+
+	o := &Book{ID: 1, Title: "Go", R: &bookR{}}
+	o.Track()
+	c := o.Copy()
+	c.Title = "changed"
+
+	if o.Title != "Go" {
+		t.Error("copy should not share values with the original")
+	}
+	if c.R != nil || c.snapshot != nil {
+		t.Error("copy should not carry loaded relationships or the snapshot")
+	}
+}
+
+func TestBookUpdateChanged(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	o := &Book{Title: "before", AuthorName: "author", PublishedYear: null.IntFrom(2000)}
+	if err := o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := FindBook(ctx, tx, o.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := loaded.Snapshot(); !ok {
+		t.Fatal("FindBook should take a snapshot")
+	}
+	all, err := Books(BookWhere.ID.EQ(o.ID)).All(boil.SkipHooks(ctx), tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := all[0].Snapshot(); !ok {
+		t.Error("All should take a snapshot even when hooks are skipped")
+	}
+
+	// 別の経路で author が変わっても、title だけの UPDATE なら上書きしない
+	if _, err := Books(BookWhere.ID.EQ(o.ID)).UpdateAll(ctx, tx, M{BookColumns.AuthorName: "concurrent"}); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded.Title = "after"
+	rowsAff, err := loaded.UpdateChanged(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rowsAff != 1 {
+		t.Errorf("want 1 row updated, got %d", rowsAff)
+	}
+	if changes := loaded.Changed(); len(changes) != 0 {
+		t.Errorf("want no changes after UpdateChanged, got %v", changes)
+	}
+
+	key := makeCacheKey(boil.Whitelist(BookColumns.Title), nil)
+	bookUpdateCacheMut.RLock()
+	_, cached := bookUpdateCache[key]
+	bookUpdateCacheMut.RUnlock()
+	if !cached {
+		t.Error("UpdateChanged should cache the statement by its column set")
+	}
+
+	if rowsAff, err := loaded.UpdateChanged(ctx, tx); err != nil || rowsAff != 0 {
+		t.Errorf("unchanged book should not be updated: %d, %v", rowsAff, err)
+	}
+
+	if err := o.Reload(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	if o.Title != "after" || o.AuthorName != "concurrent" {
+		t.Errorf("got title %q author %q", o.Title, o.AuthorName)
+	}
+}
+
+func TestBookChangedNullColumn(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	o := &Book{Title: "untitled", AuthorName: "anonymous"}
+	if err := o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	// 読み直した NULL の published_year を変更とみなさない
+	if err := o.Reload(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	if o.PublishedYear.Valid {
+		t.Fatalf("published_year = %v, want NULL", o.PublishedYear)
+	}
+	if changes := o.Changed(); len(changes) != 0 {
+		t.Errorf("want no changes, got %v", changes)
+	}
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+// AllLocked は lock の行ロック句を付けて All を実行する。exec はトランザクションでなければならない。
+func (q bookQuery) AllLocked(ctx context.Context, exec boil.ContextExecutor, lock LockMod) (BookSlice, error) {
+	if err := checkLockExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	return q.locked(lock).All(ctx, exec)
+}
+
+// OneLocked は lock の行ロック句を付けて One を実行する。exec はトランザクションでなければならない。
+// SkipLocked を指定して対象行が全てロック中の場合は sql.ErrNoRows を返す。
+func (q bookQuery) OneLocked(ctx context.Context, exec boil.ContextExecutor, lock LockMod) (*Book, error) {
+	if err := checkLockExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	return q.locked(lock).One(ctx, exec)
+}
+
+// locked は q を複製して lock を付ける。q.Query は呼び出し元と共有しているので、元のクエリにはロック句を残さない。
+func (q bookQuery) locked(lock LockMod) bookQuery {
+	qc := *q.Query
+	lock.Apply(&qc)
+	return bookQuery{&qc}
+}
+
+// FindBookForUpdate は FindBook の SELECT ... FOR UPDATE 版
+func FindBookForUpdate(ctx context.Context, exec boil.ContextExecutor, iD int, selectCols ...string) (*Book, error) {
+	return FindBookLocked(ctx, exec, ForUpdate(), iD, selectCols...)
+}
+
+// FindBookLocked は lock の行ロック句を付けて ID で 1 件取得する。exec はトランザクションでなければならない。
+// SkipLocked を指定して行がロック中の場合は sql.ErrNoRows を返す。
+func FindBookLocked(ctx context.Context, exec boil.ContextExecutor, lock LockMod, iD int, selectCols ...string) (*Book, error) {
+	if err := checkLockExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	bookObj := &Book{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"books\" where \"id\"=$1 for %s", sel, lock.clause(),
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, bookObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from books")
+	}
+
+	if err = bookObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return bookObj, err
+	}
+	bookObj.Track()
+
+	return bookObj, nil
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func insertCommittedBooks(t *testing.T, n int) BookSlice {
+	t.Helper()
+
+	ctx := context.Background()
+	seed := randomize.NewSeed()
+
+	var books BookSlice
+	for i := 0; i < n; i++ {
+		o := &Book{}
+		if err := randomize.Struct(seed, o, bookDBTypes, true, bookColumnsWithDefault...); err != nil {
+			t.Fatalf("Unable to randomize Book struct: %s", err)
+		}
+		if err := o.Insert(ctx, boil.GetContextDB(), boil.Infer()); err != nil {
+			t.Fatal(err)
+		}
+		books = append(books, o)
+	}
+
+	t.Cleanup(func() {
+		if _, err := books.DeleteAll(ctx, boil.GetContextDB()); err != nil {
+			t.Error(err)
+		}
+	})
+
+	return books
+}
+
+func TestBooksSkipLocked(t *testing.T) {
+	books := insertCommittedBooks(t, 4)
+	ids := make([]int, len(books))
+	for i, b := range books {
+		ids[i] = b.ID
+	}
+
+	ctx := context.Background()
+
+	// ワーカーを並行に動かし、SKIP LOCKED で 1 件ずつ確保させる
+	const workers = 4
+	claimed := make(chan int, workers)
+	ready := &sync.WaitGroup{}
+	ready.Add(workers)
+	release := make(chan struct{})
+	errs := make(chan error, workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			tx, err := boil.BeginTx(ctx, nil)
+			if err != nil {
+				ready.Done()
+				errs <- err
+				return
+			}
+			defer func() { _ = tx.Rollback() }()
+
+			b, err := Books(BookWhere.ID.IN(ids)).OneLocked(ctx, tx, ForUpdate().SkipLocked())
+			ready.Done()
+			if err != nil {
+				errs <- err
+				return
+			}
+			claimed <- b.ID
+			<-release
+			errs <- nil
+		}()
+	}
+
+	ready.Wait()
+	close(release)
+	for i := 0; i < workers; i++ {
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(claimed)
+
+	seen := map[int]bool{}
+	for id := range claimed {
+		if seen[id] {
+			t.Errorf("book %d was claimed by more than one worker", id)
+		}
+		seen[id] = true
+	}
+	if len(seen) != workers {
+		t.Errorf("want %d distinct books claimed, got %d", workers, len(seen))
+	}
+}
+
+func TestFindBookForUpdateWaitPolicies(t *testing.T) {
+	book := insertCommittedBooks(t, 1)[0]
+	ctx := context.Background()
+
+	holder := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = holder.Rollback() }()
+
+	if _, err := FindBookForUpdate(ctx, holder, book.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := FindBookLocked(ctx, tx, ForUpdate().SkipLocked(), book.ID); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("SKIP LOCKED: want sql.ErrNoRows, got %v", err)
+	}
+
+	_, err := FindBookLocked(ctx, tx, ForUpdate().NoWait(), book.ID)
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != "55P03" {
+		t.Errorf("NOWAIT: want lock_not_available, got %v", err)
+	}
+}
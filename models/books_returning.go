@@ -0,0 +1,185 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+// UpdateAll / DeleteAll に RETURNING を付け、更新・削除した行を BookSlice で返す。
+// 監査ログやキャッシュの無効化のように、変わった行そのものが必要な場合に使う。
+// 一括操作のフック（RegisterBookBulkHook）も UpdateAll / DeleteAll と同じように実行する。
+
+// bookReturning は sql に RETURNING "books".* を付けて実行し、返った行を BookSlice にする。
+// 返った行は SELECT で読んだ行ではないので AfterSelect フックは実行しない。スナップショットは取る。
+func bookReturning(ctx context.Context, exec boil.ContextExecutor, sql string, args []interface{}) (BookSlice, error) {
+	sql = strings.TrimSuffix(sql, ";") + " RETURNING \"books\".*"
+
+	var o BookSlice
+	if err := queries.Raw(sql, args...).Bind(ctx, exec, &o); err != nil {
+		return nil, err
+	}
+
+	for _, obj := range o {
+		obj.Track()
+	}
+
+	return o, nil
+}
+
+// UpdateAllReturning は UpdateAll と同じ更新を行い、更新後の行を返す。返した行ごとに AfterUpdate フックを実行する。
+func (q bookQuery) UpdateAllReturning(ctx context.Context, exec boil.ContextExecutor, cols M) (BookSlice, error) {
+	op := &BookBulkOp{Query: q.Query, Cols: cols}
+	if err := doBookBulkHooks(ctx, exec, BeforeUpdateAllHook, op); err != nil {
+		return nil, err
+	}
+
+	if len(op.Cols) == 0 {
+		return nil, errors.New("models: update all requires at least one column argument")
+	}
+
+	queries.SetUpdate(q.Query, op.Cols)
+
+	sql, args := queries.BuildQuery(q.Query)
+	o, err := bookReturning(ctx, exec, sql, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: unable to update all for books")
+	}
+
+	for _, obj := range o {
+		if err := obj.doAfterUpdateHooks(ctx, exec); err != nil {
+			return o, err
+		}
+	}
+
+	op.RowsAffected = int64(len(o))
+	return o, doBookBulkHooks(ctx, exec, AfterUpdateAllHook, op)
+}
+
+// UpdateAllReturning は UpdateAll と同じ更新を行い、更新後の行を返す。返した行ごとに AfterUpdate フックを実行する。
+// o 自体の値は書き換えない。
+func (o BookSlice) UpdateAllReturning(ctx context.Context, exec boil.ContextExecutor, cols M) (BookSlice, error) {
+	if len(o) == 0 {
+		return nil, nil
+	}
+
+	op := &BookBulkOp{Slice: o, Cols: cols}
+	if err := doBookBulkHooks(ctx, exec, BeforeUpdateAllHook, op); err != nil {
+		return nil, err
+	}
+	cols = op.Cols
+
+	if len(cols) == 0 {
+		return nil, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), bookPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"books\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, bookPrimaryKeyColumns, len(o)))
+
+	updated, err := bookReturning(ctx, exec, sql, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: unable to update all in book slice")
+	}
+
+	for _, obj := range updated {
+		if err := obj.doAfterUpdateHooks(ctx, exec); err != nil {
+			return updated, err
+		}
+	}
+
+	op.RowsAffected = int64(len(updated))
+	return updated, doBookBulkHooks(ctx, exec, AfterUpdateAllHook, op)
+}
+
+// DeleteAllReturning は DeleteAll と同じ削除を行い、削除した行を返す。返した行ごとに AfterDelete フックを実行する。
+func (q bookQuery) DeleteAllReturning(ctx context.Context, exec boil.ContextExecutor) (BookSlice, error) {
+	if q.Query == nil {
+		return nil, errors.New("models: no bookQuery provided for delete all")
+	}
+
+	op := &BookBulkOp{Query: q.Query}
+	if err := doBookBulkHooks(ctx, exec, BeforeDeleteAllHook, op); err != nil {
+		return nil, err
+	}
+
+	queries.SetDelete(q.Query)
+
+	sql, args := queries.BuildQuery(q.Query)
+	o, err := bookReturning(ctx, exec, sql, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: unable to delete all from books")
+	}
+
+	for _, obj := range o {
+		if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+			return o, err
+		}
+	}
+
+	op.RowsAffected = int64(len(o))
+	return o, doBookBulkHooks(ctx, exec, AfterDeleteAllHook, op)
+}
+
+// DeleteAllReturning は DeleteAll と同じ削除を行い、削除した行を返す。
+// DeleteAll と同じく o の各行で BeforeDelete フックを、返した行ごとに AfterDelete フックを実行する。
+func (o BookSlice) DeleteAllReturning(ctx context.Context, exec boil.ContextExecutor) (BookSlice, error) {
+	if len(o) == 0 {
+		return nil, nil
+	}
+
+	op := &BookBulkOp{Slice: o}
+	if err := doBookBulkHooks(ctx, exec, BeforeDeleteAllHook, op); err != nil {
+		return nil, err
+	}
+
+	for _, obj := range o {
+		if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+			return nil, err
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), bookPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"books\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, bookPrimaryKeyColumns, len(o))
+
+	deleted, err := bookReturning(ctx, exec, sql, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: unable to delete all from book slice")
+	}
+
+	for _, obj := range deleted {
+		if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+			return deleted, err
+		}
+	}
+
+	op.RowsAffected = int64(len(deleted))
+	return deleted, doBookBulkHooks(ctx, exec, AfterDeleteAllHook, op)
+}
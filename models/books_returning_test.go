@@ -0,0 +1,153 @@
+package models
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func insertBooks(t *testing.T, ctx context.Context, exec boil.ContextExecutor, n int) BookSlice {
+	t.Helper()
+
+	seed := randomize.NewSeed()
+	var books BookSlice
+	for i := 0; i < n; i++ {
+		o := &Book{}
+		if err := randomize.Struct(seed, o, bookDBTypes, true, bookColumnsWithDefault...); err != nil {
+			t.Fatalf("Unable to randomize Book struct: %s", err)
+		}
+		if err := o.Insert(ctx, exec, boil.Infer()); err != nil {
+			t.Fatal(err)
+		}
+		books = append(books, o)
+	}
+
+	return books
+}
+
+func bookIDs(books BookSlice) []int {
+	ids := make([]int, len(books))
+	for i, b := range books {
+		ids[i] = b.ID
+	}
+	sort.Ints(ids)
+
+	return ids
+}
+
+func TestBooksUpdateAllReturning(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	books := insertBooks(t, ctx, tx, 3)
+	ids := bookIDs(books)
+
+	updated, err := Books(BookWhere.ID.IN(ids[:2])).UpdateAllReturning(ctx, tx, M{BookColumns.Title: "query"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := bookIDs(updated); len(got) != 2 || got[0] != ids[0] || got[1] != ids[1] {
+		t.Errorf("updated ids = %v, want %v", got, ids[:2])
+	}
+	for _, b := range updated {
+		if b.Title != "query" {
+			t.Errorf("book %d title = %q", b.ID, b.Title)
+		}
+	}
+
+	updated, err = books.UpdateAllReturning(ctx, tx, M{BookColumns.Title: "slice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated) != 3 {
+		t.Fatalf("want 3 rows, got %d", len(updated))
+	}
+	for _, b := range updated {
+		if b.Title != "slice" {
+			t.Errorf("book %d title = %q", b.ID, b.Title)
+		}
+	}
+}
+
+func TestBooksDeleteAllReturning(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	books := insertBooks(t, ctx, tx, 4)
+	ids := bookIDs(books)
+
+	deleted, err := Books(BookWhere.ID.EQ(ids[0])).DeleteAllReturning(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deleted) != 1 || deleted[0].ID != ids[0] || len(deleted[0].Title) == 0 {
+		t.Errorf("deleted = %v, want book %d with its columns", deleted, ids[0])
+	}
+
+	rest, err := Books(BookWhere.ID.IN(ids[1:])).All(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleted, err = rest.DeleteAllReturning(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := bookIDs(deleted); len(got) != 3 {
+		t.Errorf("deleted ids = %v, want %v", got, ids[1:])
+	}
+
+	count, err := Books(BookWhere.ID.IN(ids)).Count(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Errorf("want all books deleted, %d left", count)
+	}
+}
+
+func TestBooksUpdateAllReturningSkipsSelectHooks(t *testing.T) {
+	t.Parallel()
+
+	var selected []int
+	ctx := WithHooks(context.Background(), BookContextHook(boil.AfterSelectHook, func(_ context.Context, _ boil.ContextExecutor, o *Book) error {
+		selected = append(selected, o.ID)
+		return nil
+	}))
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	books := insertBooks(t, ctx, tx, 2)
+	ids := bookIDs(books)
+
+	updated, err := Books(BookWhere.ID.IN(ids)).UpdateAllReturning(ctx, tx, M{BookColumns.Title: "returned"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// RETURNING の行は SELECT で読んだ行ではない
+	if len(selected) != 0 {
+		t.Errorf("AfterSelect hooks ran for %v, want none", selected)
+	}
+	for _, b := range updated {
+		if _, ok := b.Snapshot(); !ok {
+			t.Errorf("book %d: want a snapshot of the returned row", b.ID)
+		}
+	}
+}
+
+func TestBooksUpdateAllReturningNoColumns(t *testing.T) {
+	t.Parallel()
+
+	// 列が無ければ実行しないので exec は nil でよい
+	if _, err := Books().UpdateAllReturning(context.Background(), nil, M{}); err == nil {
+		t.Error("want an error for an empty column set")
+	}
+}
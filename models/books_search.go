@@ -0,0 +1,16 @@
+package models
+
+import "github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+// SearchBooks はタイトルか著者名に keyword を含む本をタイトル順に最大 limit 件読むクエリ。
+// 著者は authors テーブルに移行中なので、著者名は互換ビュー books_with_author から検索する。
+// 大文字と小文字は区別せず、keyword の % や _ は文字どおりに扱う。
+func SearchBooks(keyword string, limit int) bookQuery {
+	pattern := ContainsPattern(keyword)
+
+	return BooksWithAuthor(
+		qm.Where("title ILIKE ? OR author ILIKE ?", pattern, pattern),
+		qm.OrderBy("title ASC"),
+		qm.Limit(limit),
+	)
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+func TestSearchBooksEscapesWildcards(t *testing.T) {
+	t.Parallel()
+
+	sql, args := queries.BuildQuery(SearchBooks("100%_", 5).Query)
+	if !strings.Contains(sql, `(title ILIKE $1 OR author ILIKE $2)`) {
+		t.Errorf("got %s", sql)
+	}
+	if len(args) != 2 || args[0] != `%100\%\_%` || args[1] != args[0] {
+		t.Errorf("args = %v", args)
+	}
+}
@@ -0,0 +1,1226 @@
+// Code generated by SQLBoiler 4.18.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// OutboxEvent is an object representing the database table.
+type OutboxEvent struct {
+	ID            int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	AggregateType string      `boil:"aggregate_type" json:"aggregate_type" toml:"aggregate_type" yaml:"aggregate_type"`
+	AggregateID   string      `boil:"aggregate_id" json:"aggregate_id" toml:"aggregate_id" yaml:"aggregate_id"`
+	EventType     string      `boil:"event_type" json:"event_type" toml:"event_type" yaml:"event_type"`
+	Payload       types.JSON  `boil:"payload" json:"payload" toml:"payload" yaml:"payload"`
+	Attempts      int         `boil:"attempts" json:"attempts" toml:"attempts" yaml:"attempts"`
+	LastError     null.String `boil:"last_error" json:"last_error,omitempty" toml:"last_error" yaml:"last_error,omitempty"`
+	NextAttemptAt time.Time   `boil:"next_attempt_at" json:"next_attempt_at" toml:"next_attempt_at" yaml:"next_attempt_at"`
+	SentAt        null.Time   `boil:"sent_at" json:"sent_at,omitempty" toml:"sent_at" yaml:"sent_at,omitempty"`
+	CreatedAt     null.Time   `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
+	TenantID      int         `boil:"tenant_id" json:"tenant_id" toml:"tenant_id" yaml:"tenant_id"`
+
+	R *outboxEventR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L outboxEventL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+
+	// snapshot は読み込んだ時点の値。Changed や UpdateChanged の変更追跡で使う。
+	snapshot *OutboxEvent `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var OutboxEventColumns = struct {
+	ID            string
+	AggregateType string
+	AggregateID   string
+	EventType     string
+	Payload       string
+	Attempts      string
+	LastError     string
+	NextAttemptAt string
+	SentAt        string
+	CreatedAt     string
+	TenantID      string
+}{
+	ID:            "id",
+	AggregateType: "aggregate_type",
+	AggregateID:   "aggregate_id",
+	EventType:     "event_type",
+	Payload:       "payload",
+	Attempts:      "attempts",
+	LastError:     "last_error",
+	NextAttemptAt: "next_attempt_at",
+	SentAt:        "sent_at",
+	CreatedAt:     "created_at",
+	TenantID:      "tenant_id",
+}
+
+var OutboxEventTableColumns = struct {
+	ID            string
+	AggregateType string
+	AggregateID   string
+	EventType     string
+	Payload       string
+	Attempts      string
+	LastError     string
+	NextAttemptAt string
+	SentAt        string
+	CreatedAt     string
+	TenantID      string
+}{
+	ID:            "outbox_events.id",
+	AggregateType: "outbox_events.aggregate_type",
+	AggregateID:   "outbox_events.aggregate_id",
+	EventType:     "outbox_events.event_type",
+	Payload:       "outbox_events.payload",
+	Attempts:      "outbox_events.attempts",
+	LastError:     "outbox_events.last_error",
+	NextAttemptAt: "outbox_events.next_attempt_at",
+	SentAt:        "outbox_events.sent_at",
+	CreatedAt:     "outbox_events.created_at",
+	TenantID:      "outbox_events.tenant_id",
+}
+
+// Generated where
+
+type whereHelperint64 struct{ field string }
+
+func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelpertypes_JSON struct{ field string }
+
+func (w whereHelpertypes_JSON) EQ(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertypes_JSON) NEQ(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertypes_JSON) LT(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_JSON) LTE(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_JSON) GT(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_JSON) GTE(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+type whereHelpertime_Time struct{ field string }
+
+func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var OutboxEventWhere = struct {
+	ID            whereHelperint64
+	AggregateType whereHelperstring
+	AggregateID   whereHelperstring
+	EventType     whereHelperstring
+	Payload       whereHelpertypes_JSON
+	Attempts      whereHelperint
+	LastError     whereHelpernull_String
+	NextAttemptAt whereHelpertime_Time
+	SentAt        whereHelpernull_Time
+	CreatedAt     whereHelpernull_Time
+	TenantID      whereHelperint
+}{
+	ID:            whereHelperint64{field: "\"outbox_events\".\"id\""},
+	AggregateType: whereHelperstring{field: "\"outbox_events\".\"aggregate_type\""},
+	AggregateID:   whereHelperstring{field: "\"outbox_events\".\"aggregate_id\""},
+	EventType:     whereHelperstring{field: "\"outbox_events\".\"event_type\""},
+	Payload:       whereHelpertypes_JSON{field: "\"outbox_events\".\"payload\""},
+	Attempts:      whereHelperint{field: "\"outbox_events\".\"attempts\""},
+	LastError:     whereHelpernull_String{field: "\"outbox_events\".\"last_error\""},
+	NextAttemptAt: whereHelpertime_Time{field: "\"outbox_events\".\"next_attempt_at\""},
+	SentAt:        whereHelpernull_Time{field: "\"outbox_events\".\"sent_at\""},
+	CreatedAt:     whereHelpernull_Time{field: "\"outbox_events\".\"created_at\""},
+	TenantID:      whereHelperint{field: "\"outbox_events\".\"tenant_id\""},
+}
+
+// OutboxEventRels is where relationship names are stored.
+var OutboxEventRels = struct {
+}{}
+
+// outboxEventR is where relationships are stored.
+type outboxEventR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*outboxEventR) NewStruct() *outboxEventR {
+	return &outboxEventR{}
+}
+
+// outboxEventL is where Load methods for each relationship are stored.
+type outboxEventL struct{}
+
+var (
+	outboxEventAllColumns            = []string{"id", "aggregate_type", "aggregate_id", "event_type", "payload", "attempts", "last_error", "next_attempt_at", "sent_at", "created_at", "tenant_id"}
+	outboxEventColumnsWithoutDefault = []string{"aggregate_type", "aggregate_id", "event_type", "payload"}
+	outboxEventColumnsWithDefault    = []string{"id", "attempts", "last_error", "next_attempt_at", "sent_at", "created_at", "tenant_id"}
+	outboxEventPrimaryKeyColumns     = []string{"id"}
+	outboxEventGeneratedColumns      = []string{}
+)
+
+type (
+	// OutboxEventSlice is an alias for a slice of pointers to OutboxEvent.
+	// This should almost always be used instead of []OutboxEvent.
+	OutboxEventSlice []*OutboxEvent
+	// OutboxEventHook is the signature for custom OutboxEvent hook methods
+	OutboxEventHook func(context.Context, boil.ContextExecutor, *OutboxEvent) error
+
+	outboxEventQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	outboxEventType                 = reflect.TypeOf(&OutboxEvent{})
+	outboxEventMapping              = queries.MakeStructMapping(outboxEventType)
+	outboxEventPrimaryKeyMapping, _ = queries.BindMapping(outboxEventType, outboxEventMapping, outboxEventPrimaryKeyColumns)
+	outboxEventInsertCacheMut       sync.RWMutex
+	outboxEventInsertCache          = make(map[string]insertCache)
+	outboxEventUpdateCacheMut       sync.RWMutex
+	outboxEventUpdateCache          = make(map[string]updateCache)
+	outboxEventUpsertCacheMut       sync.RWMutex
+	outboxEventUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var outboxEventAfterSelectMu sync.Mutex
+var outboxEventAfterSelectHooks []OutboxEventHook
+
+var outboxEventBeforeInsertMu sync.Mutex
+var outboxEventBeforeInsertHooks []OutboxEventHook
+var outboxEventAfterInsertMu sync.Mutex
+var outboxEventAfterInsertHooks []OutboxEventHook
+
+var outboxEventBeforeUpdateMu sync.Mutex
+var outboxEventBeforeUpdateHooks []OutboxEventHook
+var outboxEventAfterUpdateMu sync.Mutex
+var outboxEventAfterUpdateHooks []OutboxEventHook
+
+var outboxEventBeforeDeleteMu sync.Mutex
+var outboxEventBeforeDeleteHooks []OutboxEventHook
+var outboxEventAfterDeleteMu sync.Mutex
+var outboxEventAfterDeleteHooks []OutboxEventHook
+
+var outboxEventBeforeUpsertMu sync.Mutex
+var outboxEventBeforeUpsertHooks []OutboxEventHook
+var outboxEventAfterUpsertMu sync.Mutex
+var outboxEventAfterUpsertHooks []OutboxEventHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *OutboxEvent) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxEventAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return outboxEventHooks.run(ctx, exec, boil.AfterSelectHook, o)
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *OutboxEvent) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxEventBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return outboxEventHooks.run(ctx, exec, boil.BeforeInsertHook, o)
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *OutboxEvent) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxEventAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return outboxEventHooks.run(ctx, exec, boil.AfterInsertHook, o)
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *OutboxEvent) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxEventBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return outboxEventHooks.run(ctx, exec, boil.BeforeUpdateHook, o)
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *OutboxEvent) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxEventAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return outboxEventHooks.run(ctx, exec, boil.AfterUpdateHook, o)
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *OutboxEvent) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxEventBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return outboxEventHooks.run(ctx, exec, boil.BeforeDeleteHook, o)
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *OutboxEvent) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxEventAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return outboxEventHooks.run(ctx, exec, boil.AfterDeleteHook, o)
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *OutboxEvent) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxEventBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return outboxEventHooks.run(ctx, exec, boil.BeforeUpsertHook, o)
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *OutboxEvent) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range outboxEventAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return outboxEventHooks.run(ctx, exec, boil.AfterUpsertHook, o)
+}
+
+// AddOutboxEventHook registers your hook function for all future operations.
+func AddOutboxEventHook(hookPoint boil.HookPoint, outboxEventHook OutboxEventHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		outboxEventAfterSelectMu.Lock()
+		outboxEventAfterSelectHooks = append(outboxEventAfterSelectHooks, outboxEventHook)
+		outboxEventAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		outboxEventBeforeInsertMu.Lock()
+		outboxEventBeforeInsertHooks = append(outboxEventBeforeInsertHooks, outboxEventHook)
+		outboxEventBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		outboxEventAfterInsertMu.Lock()
+		outboxEventAfterInsertHooks = append(outboxEventAfterInsertHooks, outboxEventHook)
+		outboxEventAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		outboxEventBeforeUpdateMu.Lock()
+		outboxEventBeforeUpdateHooks = append(outboxEventBeforeUpdateHooks, outboxEventHook)
+		outboxEventBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		outboxEventAfterUpdateMu.Lock()
+		outboxEventAfterUpdateHooks = append(outboxEventAfterUpdateHooks, outboxEventHook)
+		outboxEventAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		outboxEventBeforeDeleteMu.Lock()
+		outboxEventBeforeDeleteHooks = append(outboxEventBeforeDeleteHooks, outboxEventHook)
+		outboxEventBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		outboxEventAfterDeleteMu.Lock()
+		outboxEventAfterDeleteHooks = append(outboxEventAfterDeleteHooks, outboxEventHook)
+		outboxEventAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		outboxEventBeforeUpsertMu.Lock()
+		outboxEventBeforeUpsertHooks = append(outboxEventBeforeUpsertHooks, outboxEventHook)
+		outboxEventBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		outboxEventAfterUpsertMu.Lock()
+		outboxEventAfterUpsertHooks = append(outboxEventAfterUpsertHooks, outboxEventHook)
+		outboxEventAfterUpsertMu.Unlock()
+	}
+}
+
+var outboxEventHooks = &hookRegistry[boil.HookPoint, *OutboxEvent]{table: "outbox_events"}
+
+// RegisterOutboxEventHook は OutboxEvent のフックを登録し、登録を外す関数を返す
+func RegisterOutboxEventHook(hookPoint boil.HookPoint, hook OutboxEventHook, opts ...HookOption) (unregister func()) {
+	return outboxEventHooks.register(hookPoint, hook, opts)
+}
+
+// OutboxEventContextHook は WithHooks で context に載せる OutboxEvent のフックを作る
+func OutboxEventContextHook(hookPoint boil.HookPoint, hook OutboxEventHook, opts ...HookOption) ContextHook {
+	return outboxEventHooks.contextHook(hookPoint, hook, opts)
+}
+
+// OutboxEventBulkOp は一括操作（UpdateAll / DeleteAll）の内容。クエリに対する操作なら Query、スライスに対する操作なら Slice が入る。
+type OutboxEventBulkOp struct {
+	Query *queries.Query
+	Slice OutboxEventSlice
+	// Cols は UpdateAll に渡された値。Before フックで書き換えると実行する UPDATE に反映される。DeleteAll では nil。
+	Cols M
+	// RowsAffected は実際に変わった行数。After フックでだけ入る。
+	RowsAffected int64
+}
+
+// OutboxEventBulkHook は一括操作のフック。Before フックがエラーを返すと操作を取りやめ、そのエラーを返す。
+type OutboxEventBulkHook func(context.Context, boil.ContextExecutor, *OutboxEventBulkOp) error
+
+var outboxEventBulkHooks = &hookRegistry[BulkHookPoint, *OutboxEventBulkOp]{table: "outbox_events"}
+
+// RegisterOutboxEventBulkHook は OutboxEvent の一括操作のフックを登録し、登録を外す関数を返す
+func RegisterOutboxEventBulkHook(hookPoint BulkHookPoint, hook OutboxEventBulkHook, opts ...HookOption) (unregister func()) {
+	return outboxEventBulkHooks.register(hookPoint, hook, opts)
+}
+
+// OutboxEventBulkContextHook は WithHooks で context に載せる OutboxEvent の一括操作のフックを作る
+func OutboxEventBulkContextHook(hookPoint BulkHookPoint, hook OutboxEventBulkHook, opts ...HookOption) ContextHook {
+	return outboxEventBulkHooks.contextHook(hookPoint, hook, opts)
+}
+
+// doOutboxEventBulkHooks は hookPoint の登録済みのフックと ctx のフックを実行する。boil.SkipHooks した context では何もしない。
+func doOutboxEventBulkHooks(ctx context.Context, exec boil.ContextExecutor, hookPoint BulkHookPoint, op *OutboxEventBulkOp) error {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	return outboxEventBulkHooks.run(ctx, exec, hookPoint, op)
+}
+
+// One returns a single outboxEvent record from the query.
+func (q outboxEventQuery) One(ctx context.Context, exec boil.ContextExecutor) (*OutboxEvent, error) {
+	o := &OutboxEvent{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for outbox_events")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+	o.Track()
+
+	return o, nil
+}
+
+// All returns all OutboxEvent records from the query.
+func (q outboxEventQuery) All(ctx context.Context, exec boil.ContextExecutor) (OutboxEventSlice, error) {
+	var o []*OutboxEvent
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to OutboxEvent slice")
+	}
+
+	for _, obj := range o {
+		if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+			return o, err
+		}
+		obj.Track()
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all OutboxEvent records in the query.
+func (q outboxEventQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count outbox_events rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q outboxEventQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if outbox_events exists")
+	}
+
+	return count > 0, nil
+}
+
+// OutboxEvents retrieves all the records using an executor.
+func OutboxEvents(mods ...qm.QueryMod) outboxEventQuery {
+	mods = append(mods, qm.From("\"outbox_events\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"outbox_events\".*"})
+	}
+
+	return outboxEventQuery{q}
+}
+
+// FindOutboxEvent retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindOutboxEvent(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*OutboxEvent, error) {
+	outboxEventObj := &OutboxEvent{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"outbox_events\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, outboxEventObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from outbox_events")
+	}
+
+	if err = outboxEventObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return outboxEventObj, err
+	}
+	outboxEventObj.Track()
+
+	return outboxEventObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *OutboxEvent) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no outbox_events provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if queries.MustTime(o.CreatedAt).IsZero() {
+			queries.SetScanner(&o.CreatedAt, currTime)
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(outboxEventColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	outboxEventInsertCacheMut.RLock()
+	cache, cached := outboxEventInsertCache[key]
+	outboxEventInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			outboxEventAllColumns,
+			outboxEventColumnsWithDefault,
+			outboxEventColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(outboxEventType, outboxEventMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(outboxEventType, outboxEventMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"outbox_events\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"outbox_events\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into outbox_events")
+	}
+
+	if !cached {
+		outboxEventInsertCacheMut.Lock()
+		outboxEventInsertCache[key] = cache
+		outboxEventInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the OutboxEvent.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *OutboxEvent) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	outboxEventUpdateCacheMut.RLock()
+	cache, cached := outboxEventUpdateCache[key]
+	outboxEventUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			outboxEventAllColumns,
+			outboxEventPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update outbox_events, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"outbox_events\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, outboxEventPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(outboxEventType, outboxEventMapping, append(wl, outboxEventPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update outbox_events row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for outbox_events")
+	}
+
+	if !cached {
+		outboxEventUpdateCacheMut.Lock()
+		outboxEventUpdateCache[key] = cache
+		outboxEventUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q outboxEventQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	op := &OutboxEventBulkOp{Query: q.Query, Cols: cols}
+	if err := doOutboxEventBulkHooks(ctx, exec, BeforeUpdateAllHook, op); err != nil {
+		return 0, err
+	}
+
+	queries.SetUpdate(q.Query, op.Cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for outbox_events")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for outbox_events")
+	}
+
+	op.RowsAffected = rowsAff
+	if err := doOutboxEventBulkHooks(ctx, exec, AfterUpdateAllHook, op); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o OutboxEventSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	op := &OutboxEventBulkOp{Slice: o, Cols: cols}
+	if err := doOutboxEventBulkHooks(ctx, exec, BeforeUpdateAllHook, op); err != nil {
+		return 0, err
+	}
+	cols = op.Cols
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), outboxEventPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"outbox_events\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, outboxEventPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in outboxEvent slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all outboxEvent")
+	}
+	op.RowsAffected = rowsAff
+	if err := doOutboxEventBulkHooks(ctx, exec, AfterUpdateAllHook, op); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+var outboxEventAllColumnsMapping, _ = queries.BindMapping(outboxEventType, outboxEventMapping, outboxEventAllColumns)
+
+// Track は現在の値をスナップショットにする。読み込んだ OutboxEvent では自動で取るので、Insert の後などに追跡を始めるときに使う。
+func (o *OutboxEvent) Track() {
+	o.snapshot = o.Copy()
+}
+
+// Snapshot は読み込んだ時点の値を返す。追跡していなければ ok は false になる。
+func (o *OutboxEvent) Snapshot() (snapshot *OutboxEvent, ok bool) {
+	if o.snapshot == nil {
+		return nil, false
+	}
+
+	return o.snapshot.Copy(), true
+}
+
+// Copy はカラムの値を複製した OutboxEvent を返す。R（読み込んだリレーション）とスナップショットは複製しない。
+func (o *OutboxEvent) Copy() *OutboxEvent {
+	c := *o
+	c.R = nil
+	c.snapshot = nil
+
+	return &c
+}
+
+// DiffOutboxEvents は a から b への変更をカラム順に返す
+func DiffOutboxEvents(a, b *OutboxEvent) []ColumnChange {
+	return diffColumns(outboxEventAllColumns,
+		queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(a)), outboxEventAllColumnsMapping),
+		queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(b)), outboxEventAllColumnsMapping))
+}
+
+// Changed は読み込んだ時点から変わったカラムを返す。追跡していなければ nil を返す。
+func (o *OutboxEvent) Changed() []ColumnChange {
+	if o.snapshot == nil {
+		return nil
+	}
+
+	return DiffOutboxEvents(o.snapshot, o)
+}
+
+// UpdateChanged は読み込んだ時点から変わったカラムだけを UPDATE する。
+// 変更が無ければ何もしない。追跡していなければ Update(boil.Infer()) と同じになる。
+// 列の組み合わせごとに Update と同じ outboxEventUpdateCache を使う。
+// BeforeUpdate フックが変えたカラムは書き込まれないので、そうしたカラムは Update で書く。
+func (o *OutboxEvent) UpdateChanged(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o.snapshot == nil {
+		return o.Update(ctx, exec, boil.Infer())
+	}
+
+	cols, err := changedColumns("outbox_events", DiffOutboxEvents(o.snapshot, o), outboxEventPrimaryKeyColumns)
+	if err != nil || len(cols) == 0 {
+		return 0, err
+	}
+
+	rowsAff, err := o.Update(ctx, exec, boil.Whitelist(cols...))
+	if err != nil {
+		return rowsAff, err
+	}
+
+	o.Track()
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *OutboxEvent) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no outbox_events provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if queries.MustTime(o.CreatedAt).IsZero() {
+			queries.SetScanner(&o.CreatedAt, currTime)
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(outboxEventColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	outboxEventUpsertCacheMut.RLock()
+	cache, cached := outboxEventUpsertCache[key]
+	outboxEventUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			outboxEventAllColumns,
+			outboxEventColumnsWithDefault,
+			outboxEventColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			outboxEventAllColumns,
+			outboxEventPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert outbox_events, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(outboxEventAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(outboxEventPrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert outbox_events, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(outboxEventPrimaryKeyColumns))
+			copy(conflict, outboxEventPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"outbox_events\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(outboxEventType, outboxEventMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(outboxEventType, outboxEventMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert outbox_events")
+	}
+
+	if !cached {
+		outboxEventUpsertCacheMut.Lock()
+		outboxEventUpsertCache[key] = cache
+		outboxEventUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single OutboxEvent record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *OutboxEvent) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no OutboxEvent provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), outboxEventPrimaryKeyMapping)
+	sql := "DELETE FROM \"outbox_events\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from outbox_events")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for outbox_events")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q outboxEventQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no outboxEventQuery provided for delete all")
+	}
+
+	op := &OutboxEventBulkOp{Query: q.Query}
+	if err := doOutboxEventBulkHooks(ctx, exec, BeforeDeleteAllHook, op); err != nil {
+		return 0, err
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from outbox_events")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for outbox_events")
+	}
+
+	op.RowsAffected = rowsAff
+	if err := doOutboxEventBulkHooks(ctx, exec, AfterDeleteAllHook, op); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o OutboxEventSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	op := &OutboxEventBulkOp{Slice: o}
+	if err := doOutboxEventBulkHooks(ctx, exec, BeforeDeleteAllHook, op); err != nil {
+		return 0, err
+	}
+
+	for _, obj := range o {
+		if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+			return 0, err
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), outboxEventPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"outbox_events\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, outboxEventPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from outboxEvent slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for outbox_events")
+	}
+
+	for _, obj := range o {
+		if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+			return 0, err
+		}
+	}
+
+	op.RowsAffected = rowsAff
+	if err := doOutboxEventBulkHooks(ctx, exec, AfterDeleteAllHook, op); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *OutboxEvent) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindOutboxEvent(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *OutboxEventSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := OutboxEventSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), outboxEventPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"outbox_events\".* FROM \"outbox_events\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, outboxEventPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in OutboxEventSlice")
+	}
+	for _, obj := range slice {
+		obj.Track()
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// OutboxEventExists checks if the OutboxEvent row exists.
+func OutboxEventExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"outbox_events\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if outbox_events exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the OutboxEvent row exists.
+func (o *OutboxEvent) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return OutboxEventExists(ctx, exec, o.ID)
+}
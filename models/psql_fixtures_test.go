@@ -0,0 +1,57 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// 生成テストのランダムな行は外部キーに乱数が入るので、INSERT や UPDATE の前に参照先を入れて向け直す。
+// テスト用 DB でも外部キーは遅延させないので、親より先に子を入れるとその場で失敗する。
+
+// insertBookParents は o.AuthorID が NULL でなければ著者を入れて、o をその著者に向ける
+func insertBookParents(t *testing.T, ctx context.Context, exec boil.ContextExecutor, o *Book) {
+	t.Helper()
+
+	if !o.AuthorID.Valid {
+		return
+	}
+
+	author := &Author{}
+	if err := randomize.Struct(randomize.NewSeed(), author, authorDBTypes, false, authorColumnsWithDefault...); err != nil {
+		t.Fatalf("Unable to randomize Author struct: %s", err)
+	}
+	if err := author.Insert(ctx, exec, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	o.AuthorID = null.IntFrom(author.ID)
+}
+
+// insertUserFavoriteMovieParents はユーザーと映画を入れて、o をそれに向ける
+func insertUserFavoriteMovieParents(t *testing.T, ctx context.Context, exec boil.ContextExecutor, o *UserFavoriteMovie) {
+	t.Helper()
+
+	seed := randomize.NewSeed()
+	user := &User{}
+	if err := randomize.Struct(seed, user, userDBTypes, false, userColumnsWithDefault...); err != nil {
+		t.Fatalf("Unable to randomize User struct: %s", err)
+	}
+	if err := user.Insert(ctx, exec, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	movie := &Movie{}
+	if err := randomize.Struct(seed, movie, movieDBTypes, false, movieColumnsWithDefault...); err != nil {
+		t.Fatalf("Unable to randomize Movie struct: %s", err)
+	}
+	if err := movie.Insert(ctx, exec, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	o.UserID = user.ID
+	o.MovieID = movie.ID
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/friendsofgo/errors"
+	"github.com/lib/pq"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func TestForeignKeysEnforced(t *testing.T) {
+	t.Parallel()
+
+	db := isolatedDB(t)
+	ctx := context.Background()
+
+	// 外部キーは遅延させないので、親の無い行は INSERT の時点で弾かれる
+	o := &UserFavoriteMovie{UserID: 1, MovieID: 1}
+	err := o.Insert(ctx, db, boil.Infer())
+	pqErr, ok := errors.Cause(err).(*pq.Error)
+	if !ok || pqErr.Code != "23503" {
+		t.Errorf("expected foreign_key_violation on insert, got %v", err)
+	}
+}
+
+func TestIsolatedDBsDoNotShareRows(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	a, b := isolatedDB(t), isolatedDB(t)
+
+	o := &Book{Title: "isolated", AuthorName: "tester"}
+	if err := o.Insert(ctx, a, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := Books().Count(ctx, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Errorf("expected 0 books in the other database, got %d", count)
+	}
+}
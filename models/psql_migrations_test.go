@@ -0,0 +1,131 @@
+package models
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/kat-co/vala"
+	"github.com/spf13/viper"
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/drivers/sqlboiler-psql/driver"
+
+	"sqlboiler-project/internal/testdb"
+)
+
+// clonerTester はテスト単位の DB を複製できる tester
+type clonerTester interface {
+	isolatedDB(t *testing.T) *sql.DB
+}
+
+// isolatedDB はテンプレートから複製した t 専用の DB を返す。
+// 他のテストとデータを共有せずに並行実行したい場合やコミットが必要な場合に使う。
+func isolatedDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	c, ok := dbMain.(clonerTester)
+	if !ok {
+		t.Skip("per-test databases require -test.dbmode=migrations or -test.dbmode=local")
+	}
+
+	return c.isolatedDB(t)
+}
+
+// migrationPGTester は開発用サーバー上で db/migrations からテスト用 DB を作る。
+// pg_dump を使わないので開発用 DB の状態に依存せず、外部キーも削除しない。
+type migrationPGTester struct {
+	dbConn *sql.DB
+	cloner *testdb.Cloner
+
+	dbName  string
+	host    string
+	user    string
+	pass    string
+	sslmode string
+	port    int
+
+	testDBName string
+}
+
+func (m *migrationPGTester) setup() error {
+	var err error
+
+	viper.SetDefault("psql.port", 5432)
+	viper.SetDefault("psql.sslmode", "require")
+
+	m.dbName = viper.GetString("psql.dbname")
+	m.host = viper.GetString("psql.host")
+	m.user = viper.GetString("psql.user")
+	m.pass = viper.GetString("psql.pass")
+	m.port = viper.GetInt("psql.port")
+	m.sslmode = viper.GetString("psql.sslmode")
+	m.testDBName = viper.GetString("psql.testdbname")
+
+	err = vala.BeginValidation().Validate(
+		vala.StringNotEmpty(m.user, "psql.user"),
+		vala.StringNotEmpty(m.host, "psql.host"),
+		vala.Not(vala.Equals(m.port, 0, "psql.port")),
+		vala.StringNotEmpty(m.dbName, "psql.dbname"),
+		vala.StringNotEmpty(m.sslmode, "psql.sslmode"),
+	).Check()
+
+	if err != nil {
+		return err
+	}
+
+	if len(m.testDBName) == 0 {
+		m.testDBName = randomize.StableDBName(m.dbName)
+	}
+
+	admin, err := sql.Open("postgres", m.connStr("postgres"))
+	if err != nil {
+		return err
+	}
+
+	m.cloner = &testdb.Cloner{Admin: admin, ConnStr: m.connStr, Prefix: m.dbName}
+	if err = m.cloner.EnsureTemplate(); err != nil {
+		return err
+	}
+
+	return m.cloner.Create(m.testDBName)
+}
+
+func (m *migrationPGTester) connStr(dbName string) string {
+	return driver.PSQLBuildQueryString(m.user, m.pass, dbName, m.host, m.port, m.sslmode)
+}
+
+func (m *migrationPGTester) dsn() string {
+	return m.connStr(m.testDBName)
+}
+
+func (m *migrationPGTester) isolatedDB(t *testing.T) *sql.DB {
+	return m.cloner.Isolated(t, m.testDBName)
+}
+
+func (m *migrationPGTester) conn() (*sql.DB, error) {
+	if m.dbConn != nil {
+		return m.dbConn, nil
+	}
+
+	var err error
+	m.dbConn, err = sql.Open("postgres", m.dsn())
+	if err != nil {
+		return nil, err
+	}
+
+	return m.dbConn, nil
+}
+
+func (m *migrationPGTester) teardown() error {
+	if m.dbConn != nil {
+		if err := m.dbConn.Close(); err != nil {
+			return err
+		}
+		m.dbConn = nil
+	}
+
+	if err := m.cloner.Drop(m.testDBName); err != nil {
+		return err
+	}
+
+	return m.cloner.Admin.Close()
+}
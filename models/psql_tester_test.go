@@ -0,0 +1,236 @@
+package models
+
+import (
+	"bytes"
+	"database/sql"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/drivers/sqlboiler-psql/driver"
+
+	"sqlboiler-project/internal/testdb"
+)
+
+// テスト用 DB の用意の仕方
+//
+//	-test.dbmode=dev         開発用 DB を pg_dump して複製する（デフォルト、docker-compose が必要）
+//	-test.dbmode=migrations  開発用サーバー上でマイグレーションからテンプレート DB を作り、それを複製する
+//	-test.dbmode=local       initdb/pg_ctl で一時ディレクトリにサーバーを立て、migrations と同じ手順で DB を作る
+var flagDBMode = flag.String("test.dbmode", "dev", "How to prepare the test database: dev, migrations or local")
+var flagPGBin = flag.String("test.pgbin", "", "Directory containing initdb and pg_ctl for -test.dbmode=local")
+
+// このファイルの init は psql_main_test.go の init より後に走る必要がある（ファイル名順）
+func init() {
+	dbMain = &modeTester{}
+}
+
+// dsnTester はテスト用 DB への接続文字列を返せる tester
+type dsnTester interface {
+	dsn() string
+}
+
+// modeTester は -test.dbmode に応じて tester を切り替える。フラグは TestMain の flag.Parse 後に読む。
+type modeTester struct {
+	tester
+}
+
+func (m *modeTester) setup() error {
+	switch *flagDBMode {
+	case "dev":
+		m.tester = &pgTester{}
+	case "migrations":
+		m.tester = &migrationPGTester{}
+	case "local":
+		m.tester = &localPGTester{}
+	default:
+		return errors.Errorf("unknown -test.dbmode %q", *flagDBMode)
+	}
+
+	return m.tester.setup()
+}
+
+func (m *modeTester) dsn() string {
+	return m.tester.(dsnTester).dsn()
+}
+
+func (m *modeTester) isolatedDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	c, ok := m.tester.(clonerTester)
+	if !ok {
+		t.Skip("per-test databases require -test.dbmode=migrations or -test.dbmode=local")
+	}
+
+	return c.isolatedDB(t)
+}
+
+func (p *pgTester) dsn() string {
+	return driver.PSQLBuildQueryString(p.user, p.pass, p.testDBName, p.host, p.port, p.sslmode)
+}
+
+// localPGTester はローカルにインストールされた PostgreSQL のバイナリで使い捨てのサーバーを起動する
+type localPGTester struct {
+	dbConn *sql.DB
+	cloner *testdb.Cloner
+
+	binDir  string
+	tmpDir  string
+	dataDir string
+	port    int
+	user    string
+	dbName  string
+}
+
+func (l *localPGTester) setup() (err error) {
+	if l.binDir, err = findPGBinDir(*flagPGBin); err != nil {
+		return err
+	}
+	if l.tmpDir, err = os.MkdirTemp("", "sqlboiler-pg"); err != nil {
+		return errors.Wrap(err, "failed to create temp dir")
+	}
+
+	// 途中で失敗したら、起動したサーバーを止めて一時ディレクトリを消す
+	var started bool
+	var admin *sql.DB
+	defer func() {
+		if err == nil {
+			return
+		}
+		if admin != nil {
+			_ = admin.Close()
+		}
+		if started {
+			_ = l.runCmd("pg_ctl", "-D", l.dataDir, "-m", "fast", "-w", "stop")
+		}
+		_ = os.RemoveAll(l.tmpDir)
+	}()
+
+	if l.port, err = freePort(); err != nil {
+		return err
+	}
+
+	l.dataDir = filepath.Join(l.tmpDir, "data")
+	l.user = "boiler"
+	l.dbName = "sqlboiler_test"
+
+	if err = l.runCmd("initdb", "-D", l.dataDir, "-U", l.user, "-A", "trust", "-E", "UTF8", "--no-sync"); err != nil {
+		return err
+	}
+
+	// -w の待ちがタイムアウトしてもサーバーは起動していることがあるので、失敗しても止める
+	started = true
+	opts := fmt.Sprintf("-p %d -k %s -c listen_addresses=127.0.0.1 -c fsync=off", l.port, l.tmpDir)
+	if err = l.runCmd("pg_ctl", "-D", l.dataDir, "-l", filepath.Join(l.tmpDir, "postgres.log"), "-o", opts, "-w", "start"); err != nil {
+		return err
+	}
+
+	if admin, err = sql.Open("postgres", l.connStr("postgres")); err != nil {
+		return err
+	}
+
+	l.cloner = &testdb.Cloner{Admin: admin, ConnStr: l.connStr, Prefix: l.dbName}
+	if err = l.cloner.EnsureTemplate(); err != nil {
+		return err
+	}
+
+	return l.cloner.Create(l.dbName)
+}
+
+func (l *localPGTester) runCmd(name string, args ...string) error {
+	cmd := exec.Command(filepath.Join(l.binDir, name), args...)
+
+	out := &bytes.Buffer{}
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if err := cmd.Run(); err != nil {
+		fmt.Println("failed running:", name, args)
+		fmt.Println(out.String())
+		return err
+	}
+
+	return nil
+}
+
+func (l *localPGTester) connStr(dbName string) string {
+	return driver.PSQLBuildQueryString(l.user, "", dbName, "127.0.0.1", l.port, "disable")
+}
+
+func (l *localPGTester) dsn() string {
+	return l.connStr(l.dbName)
+}
+
+func (l *localPGTester) isolatedDB(t *testing.T) *sql.DB {
+	return l.cloner.Isolated(t, l.dbName)
+}
+
+func (l *localPGTester) conn() (*sql.DB, error) {
+	if l.dbConn != nil {
+		return l.dbConn, nil
+	}
+
+	var err error
+	l.dbConn, err = sql.Open("postgres", l.dsn())
+	if err != nil {
+		return nil, err
+	}
+
+	return l.dbConn, nil
+}
+
+func (l *localPGTester) teardown() error {
+	if l.dbConn != nil {
+		if err := l.dbConn.Close(); err != nil {
+			return err
+		}
+		l.dbConn = nil
+	}
+
+	if err := l.cloner.Admin.Close(); err != nil {
+		return err
+	}
+
+	if err := l.runCmd("pg_ctl", "-D", l.dataDir, "-m", "fast", "-w", "stop"); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(l.tmpDir)
+}
+
+// findPGBinDir は initdb と pg_ctl のあるディレクトリを探す
+func findPGBinDir(dir string) (string, error) {
+	if len(dir) != 0 {
+		return dir, nil
+	}
+
+	if p, err := exec.LookPath("pg_ctl"); err == nil {
+		return filepath.Dir(p), nil
+	}
+
+	// Debian/Ubuntu のパッケージは PATH に入らない
+	candidates, _ := filepath.Glob("/usr/lib/postgresql/*/bin")
+	sort.Sort(sort.Reverse(sort.StringSlice(candidates)))
+	for _, c := range candidates {
+		if _, err := os.Stat(filepath.Join(c, "pg_ctl")); err == nil {
+			return c, nil
+		}
+	}
+
+	return "", errors.New("could not find pg_ctl; install PostgreSQL or pass -test.pgbin")
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to find a free port")
+	}
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
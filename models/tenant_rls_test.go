@@ -0,0 +1,156 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"sqlboiler-project/tenant"
+)
+
+// openTenantDB はテスト用 DB に RLS 用ロールで接続する
+func openTenantDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	p, ok := dbMain.(dsnTester)
+	if !ok {
+		t.Skip("tenant tests require a tester that exposes its DSN")
+	}
+
+	// ロールはクラスタ単位なので、マイグレーションを流していない環境でも動くように作っておく
+	_, err := boil.GetContextDB().ExecContext(context.Background(), `
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'app_tenant') THEN
+        CREATE ROLE app_tenant NOLOGIN;
+    END IF;
+END
+$$;
+GRANT SELECT, INSERT, UPDATE, DELETE ON books, users, movies, user_favorite_movies, outbox_events TO app_tenant;
+GRANT USAGE ON ALL SEQUENCES IN SCHEMA public TO app_tenant;`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := tenant.Open(p.dsn(), tenant.DefaultRole)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+func TestTenantIsolation(t *testing.T) {
+	db := openTenantDB(t)
+
+	ctxA := tenant.WithTenant(context.Background(), 1001)
+	ctxB := tenant.WithTenant(context.Background(), 1002)
+
+	a := &Book{Title: "tenant a", AuthorName: "a"}
+	if err := a.Insert(ctxA, db, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	b := &Book{Title: "tenant b", AuthorName: "b"}
+	if err := b.Insert(ctxB, db, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_, _ = a.Delete(ctxA, db)
+		_, _ = b.Delete(ctxB, db)
+	})
+
+	if a.TenantID != 1001 || b.TenantID != 1002 {
+		t.Fatalf("tenant_id was not filled from the context: %d, %d", a.TenantID, b.TenantID)
+	}
+
+	books, err := Books().All(ctxA, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, o := range books {
+		if o.TenantID != 1001 {
+			t.Errorf("tenant 1001 can read book %d of tenant %d", o.ID, o.TenantID)
+		}
+	}
+
+	if _, err := FindBook(ctxA, db, b.ID); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("want sql.ErrNoRows reading another tenant's book, got %v", err)
+	}
+	if n, err := Books(BookWhere.ID.EQ(b.ID)).UpdateAll(ctxA, db, M{"title": "stolen"}); err != nil || n != 0 {
+		t.Errorf("want no rows updated across tenants, got %d (%v)", n, err)
+	}
+
+	tx, err := db.BeginTx(ctxB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if n, err := Books(BookWhere.ID.IN([]int{a.ID, b.ID})).Count(ctxB, tx); err != nil || n != 1 {
+		t.Errorf("want only tenant 1002's book inside its transaction, got %d (%v)", n, err)
+	}
+
+	if n, err := Books().Count(context.Background(), db); err != nil || n != 0 {
+		t.Errorf("want nothing visible without a tenant, got %d (%v)", n, err)
+	}
+}
+
+func TestTenantIsolationFavoritesAndOutbox(t *testing.T) {
+	db := openTenantDB(t)
+
+	ctxA := tenant.WithTenant(context.Background(), 1001)
+	ctxB := tenant.WithTenant(context.Background(), 1002)
+
+	u := &User{Name: "tenant a", Email: "tenant-a@example.com"}
+	if err := u.Insert(ctxA, db, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	m := &Movie{Title: "tenant a"}
+	if err := m.Insert(ctxA, db, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	f := &UserFavoriteMovie{UserID: u.ID, MovieID: m.ID}
+	if err := f.Insert(ctxA, db, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	e := &OutboxEvent{AggregateType: "books", AggregateID: "1", EventType: "created", Payload: []byte(`{}`)}
+	if err := e.Insert(ctxA, db, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_, _ = e.Delete(ctxA, db)
+		_, _ = f.Delete(ctxA, db)
+		_, _ = m.Delete(ctxA, db)
+		_, _ = u.Delete(ctxA, db)
+	})
+
+	if e.TenantID != 1001 {
+		t.Fatalf("outbox tenant_id was not filled from the context: %d", e.TenantID)
+	}
+
+	if n, err := UserFavoriteMovies(UserFavoriteMovieWhere.UserID.EQ(u.ID)).Count(ctxB, db); err != nil || n != 0 {
+		t.Errorf("want another tenant's favorites hidden, got %d (%v)", n, err)
+	}
+	if n, err := OutboxEvents(OutboxEventWhere.ID.EQ(e.ID)).Count(ctxB, db); err != nil || n != 0 {
+		t.Errorf("want another tenant's outbox events hidden, got %d (%v)", n, err)
+	}
+
+	// 他のテナントのユーザーにはお気に入りを付けられない
+	mb := &Movie{Title: "tenant b"}
+	if err := mb.Insert(ctxB, db, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _, _ = mb.Delete(ctxB, db) })
+	stolen := &UserFavoriteMovie{UserID: u.ID, MovieID: mb.ID}
+	if err := stolen.Insert(ctxB, db, boil.Infer()); err == nil {
+		_, _ = stolen.Delete(context.Background(), boil.GetContextDB())
+		t.Error("want a policy violation adding a favorite across tenants")
+	}
+
+	if n, err := UserFavoriteMovies(UserFavoriteMovieWhere.UserID.EQ(u.ID)).Count(ctxA, db); err != nil || n != 1 {
+		t.Errorf("want the owner tenant to see its favorite, got %d (%v)", n, err)
+	}
+}
@@ -0,0 +1,1479 @@
+// Code generated by SQLBoiler 4.18.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// UserFavoriteMovie is an object representing the database table.
+type UserFavoriteMovie struct {
+	UserID    int       `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	MovieID   int       `boil:"movie_id" json:"movie_id" toml:"movie_id" yaml:"movie_id"`
+	CreatedAt null.Time `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
+
+	R *userFavoriteMovieR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L userFavoriteMovieL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+
+	// snapshot は読み込んだ時点の値。Changed や UpdateChanged の変更追跡で使う。
+	snapshot *UserFavoriteMovie `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var UserFavoriteMovieColumns = struct {
+	UserID    string
+	MovieID   string
+	CreatedAt string
+}{
+	UserID:    "user_id",
+	MovieID:   "movie_id",
+	CreatedAt: "created_at",
+}
+
+var UserFavoriteMovieTableColumns = struct {
+	UserID    string
+	MovieID   string
+	CreatedAt string
+}{
+	UserID:    "user_favorite_movies.user_id",
+	MovieID:   "user_favorite_movies.movie_id",
+	CreatedAt: "user_favorite_movies.created_at",
+}
+
+// Generated where
+
+var UserFavoriteMovieWhere = struct {
+	UserID    whereHelperint
+	MovieID   whereHelperint
+	CreatedAt whereHelpernull_Time
+}{
+	UserID:    whereHelperint{field: "\"user_favorite_movies\".\"user_id\""},
+	MovieID:   whereHelperint{field: "\"user_favorite_movies\".\"movie_id\""},
+	CreatedAt: whereHelpernull_Time{field: "\"user_favorite_movies\".\"created_at\""},
+}
+
+// UserFavoriteMovieRels is where relationship names are stored.
+var UserFavoriteMovieRels = struct {
+	Movie string
+	User  string
+}{
+	Movie: "Movie",
+	User:  "User",
+}
+
+// userFavoriteMovieR is where relationships are stored.
+type userFavoriteMovieR struct {
+	Movie *Movie `boil:"Movie" json:"Movie" toml:"Movie" yaml:"Movie"`
+	User  *User  `boil:"User" json:"User" toml:"User" yaml:"User"`
+}
+
+// NewStruct creates a new relationship struct
+func (*userFavoriteMovieR) NewStruct() *userFavoriteMovieR {
+	return &userFavoriteMovieR{}
+}
+
+func (r *userFavoriteMovieR) GetMovie() *Movie {
+	if r == nil {
+		return nil
+	}
+	return r.Movie
+}
+
+func (r *userFavoriteMovieR) GetUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.User
+}
+
+// userFavoriteMovieL is where Load methods for each relationship are stored.
+type userFavoriteMovieL struct{}
+
+var (
+	userFavoriteMovieAllColumns            = []string{"user_id", "movie_id", "created_at"}
+	userFavoriteMovieColumnsWithoutDefault = []string{"user_id", "movie_id"}
+	userFavoriteMovieColumnsWithDefault    = []string{"created_at"}
+	userFavoriteMoviePrimaryKeyColumns     = []string{"user_id", "movie_id"}
+	userFavoriteMovieGeneratedColumns      = []string{}
+)
+
+type (
+	// UserFavoriteMovieSlice is an alias for a slice of pointers to UserFavoriteMovie.
+	// This should almost always be used instead of []UserFavoriteMovie.
+	UserFavoriteMovieSlice []*UserFavoriteMovie
+	// UserFavoriteMovieHook is the signature for custom UserFavoriteMovie hook methods
+	UserFavoriteMovieHook func(context.Context, boil.ContextExecutor, *UserFavoriteMovie) error
+
+	userFavoriteMovieQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	userFavoriteMovieType                 = reflect.TypeOf(&UserFavoriteMovie{})
+	userFavoriteMovieMapping              = queries.MakeStructMapping(userFavoriteMovieType)
+	userFavoriteMoviePrimaryKeyMapping, _ = queries.BindMapping(userFavoriteMovieType, userFavoriteMovieMapping, userFavoriteMoviePrimaryKeyColumns)
+	userFavoriteMovieInsertCacheMut       sync.RWMutex
+	userFavoriteMovieInsertCache          = make(map[string]insertCache)
+	userFavoriteMovieUpdateCacheMut       sync.RWMutex
+	userFavoriteMovieUpdateCache          = make(map[string]updateCache)
+	userFavoriteMovieUpsertCacheMut       sync.RWMutex
+	userFavoriteMovieUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var userFavoriteMovieAfterSelectMu sync.Mutex
+var userFavoriteMovieAfterSelectHooks []UserFavoriteMovieHook
+
+var userFavoriteMovieBeforeInsertMu sync.Mutex
+var userFavoriteMovieBeforeInsertHooks []UserFavoriteMovieHook
+var userFavoriteMovieAfterInsertMu sync.Mutex
+var userFavoriteMovieAfterInsertHooks []UserFavoriteMovieHook
+
+var userFavoriteMovieBeforeUpdateMu sync.Mutex
+var userFavoriteMovieBeforeUpdateHooks []UserFavoriteMovieHook
+var userFavoriteMovieAfterUpdateMu sync.Mutex
+var userFavoriteMovieAfterUpdateHooks []UserFavoriteMovieHook
+
+var userFavoriteMovieBeforeDeleteMu sync.Mutex
+var userFavoriteMovieBeforeDeleteHooks []UserFavoriteMovieHook
+var userFavoriteMovieAfterDeleteMu sync.Mutex
+var userFavoriteMovieAfterDeleteHooks []UserFavoriteMovieHook
+
+var userFavoriteMovieBeforeUpsertMu sync.Mutex
+var userFavoriteMovieBeforeUpsertHooks []UserFavoriteMovieHook
+var userFavoriteMovieAfterUpsertMu sync.Mutex
+var userFavoriteMovieAfterUpsertHooks []UserFavoriteMovieHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *UserFavoriteMovie) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userFavoriteMovieAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return userFavoriteMovieHooks.run(ctx, exec, boil.AfterSelectHook, o)
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *UserFavoriteMovie) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userFavoriteMovieBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return userFavoriteMovieHooks.run(ctx, exec, boil.BeforeInsertHook, o)
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *UserFavoriteMovie) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userFavoriteMovieAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return userFavoriteMovieHooks.run(ctx, exec, boil.AfterInsertHook, o)
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *UserFavoriteMovie) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userFavoriteMovieBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return userFavoriteMovieHooks.run(ctx, exec, boil.BeforeUpdateHook, o)
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *UserFavoriteMovie) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userFavoriteMovieAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return userFavoriteMovieHooks.run(ctx, exec, boil.AfterUpdateHook, o)
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *UserFavoriteMovie) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userFavoriteMovieBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return userFavoriteMovieHooks.run(ctx, exec, boil.BeforeDeleteHook, o)
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *UserFavoriteMovie) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userFavoriteMovieAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return userFavoriteMovieHooks.run(ctx, exec, boil.AfterDeleteHook, o)
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *UserFavoriteMovie) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userFavoriteMovieBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return userFavoriteMovieHooks.run(ctx, exec, boil.BeforeUpsertHook, o)
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *UserFavoriteMovie) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range userFavoriteMovieAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return userFavoriteMovieHooks.run(ctx, exec, boil.AfterUpsertHook, o)
+}
+
+// AddUserFavoriteMovieHook registers your hook function for all future operations.
+func AddUserFavoriteMovieHook(hookPoint boil.HookPoint, userFavoriteMovieHook UserFavoriteMovieHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		userFavoriteMovieAfterSelectMu.Lock()
+		userFavoriteMovieAfterSelectHooks = append(userFavoriteMovieAfterSelectHooks, userFavoriteMovieHook)
+		userFavoriteMovieAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		userFavoriteMovieBeforeInsertMu.Lock()
+		userFavoriteMovieBeforeInsertHooks = append(userFavoriteMovieBeforeInsertHooks, userFavoriteMovieHook)
+		userFavoriteMovieBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		userFavoriteMovieAfterInsertMu.Lock()
+		userFavoriteMovieAfterInsertHooks = append(userFavoriteMovieAfterInsertHooks, userFavoriteMovieHook)
+		userFavoriteMovieAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		userFavoriteMovieBeforeUpdateMu.Lock()
+		userFavoriteMovieBeforeUpdateHooks = append(userFavoriteMovieBeforeUpdateHooks, userFavoriteMovieHook)
+		userFavoriteMovieBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		userFavoriteMovieAfterUpdateMu.Lock()
+		userFavoriteMovieAfterUpdateHooks = append(userFavoriteMovieAfterUpdateHooks, userFavoriteMovieHook)
+		userFavoriteMovieAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		userFavoriteMovieBeforeDeleteMu.Lock()
+		userFavoriteMovieBeforeDeleteHooks = append(userFavoriteMovieBeforeDeleteHooks, userFavoriteMovieHook)
+		userFavoriteMovieBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		userFavoriteMovieAfterDeleteMu.Lock()
+		userFavoriteMovieAfterDeleteHooks = append(userFavoriteMovieAfterDeleteHooks, userFavoriteMovieHook)
+		userFavoriteMovieAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		userFavoriteMovieBeforeUpsertMu.Lock()
+		userFavoriteMovieBeforeUpsertHooks = append(userFavoriteMovieBeforeUpsertHooks, userFavoriteMovieHook)
+		userFavoriteMovieBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		userFavoriteMovieAfterUpsertMu.Lock()
+		userFavoriteMovieAfterUpsertHooks = append(userFavoriteMovieAfterUpsertHooks, userFavoriteMovieHook)
+		userFavoriteMovieAfterUpsertMu.Unlock()
+	}
+}
+
+var userFavoriteMovieHooks = &hookRegistry[boil.HookPoint, *UserFavoriteMovie]{table: "user_favorite_movies"}
+
+// RegisterUserFavoriteMovieHook は UserFavoriteMovie のフックを登録し、登録を外す関数を返す
+func RegisterUserFavoriteMovieHook(hookPoint boil.HookPoint, hook UserFavoriteMovieHook, opts ...HookOption) (unregister func()) {
+	return userFavoriteMovieHooks.register(hookPoint, hook, opts)
+}
+
+// UserFavoriteMovieContextHook は WithHooks で context に載せる UserFavoriteMovie のフックを作る
+func UserFavoriteMovieContextHook(hookPoint boil.HookPoint, hook UserFavoriteMovieHook, opts ...HookOption) ContextHook {
+	return userFavoriteMovieHooks.contextHook(hookPoint, hook, opts)
+}
+
+// UserFavoriteMovieBulkOp は一括操作（UpdateAll / DeleteAll）の内容。クエリに対する操作なら Query、スライスに対する操作なら Slice が入る。
+type UserFavoriteMovieBulkOp struct {
+	Query *queries.Query
+	Slice UserFavoriteMovieSlice
+	// Cols は UpdateAll に渡された値。Before フックで書き換えると実行する UPDATE に反映される。DeleteAll では nil。
+	Cols M
+	// RowsAffected は実際に変わった行数。After フックでだけ入る。
+	RowsAffected int64
+}
+
+// UserFavoriteMovieBulkHook は一括操作のフック。Before フックがエラーを返すと操作を取りやめ、そのエラーを返す。
+type UserFavoriteMovieBulkHook func(context.Context, boil.ContextExecutor, *UserFavoriteMovieBulkOp) error
+
+var userFavoriteMovieBulkHooks = &hookRegistry[BulkHookPoint, *UserFavoriteMovieBulkOp]{table: "user_favorite_movies"}
+
+// RegisterUserFavoriteMovieBulkHook は UserFavoriteMovie の一括操作のフックを登録し、登録を外す関数を返す
+func RegisterUserFavoriteMovieBulkHook(hookPoint BulkHookPoint, hook UserFavoriteMovieBulkHook, opts ...HookOption) (unregister func()) {
+	return userFavoriteMovieBulkHooks.register(hookPoint, hook, opts)
+}
+
+// UserFavoriteMovieBulkContextHook は WithHooks で context に載せる UserFavoriteMovie の一括操作のフックを作る
+func UserFavoriteMovieBulkContextHook(hookPoint BulkHookPoint, hook UserFavoriteMovieBulkHook, opts ...HookOption) ContextHook {
+	return userFavoriteMovieBulkHooks.contextHook(hookPoint, hook, opts)
+}
+
+// doUserFavoriteMovieBulkHooks は hookPoint の登録済みのフックと ctx のフックを実行する。boil.SkipHooks した context では何もしない。
+func doUserFavoriteMovieBulkHooks(ctx context.Context, exec boil.ContextExecutor, hookPoint BulkHookPoint, op *UserFavoriteMovieBulkOp) error {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	return userFavoriteMovieBulkHooks.run(ctx, exec, hookPoint, op)
+}
+
+// One returns a single userFavoriteMovie record from the query.
+func (q userFavoriteMovieQuery) One(ctx context.Context, exec boil.ContextExecutor) (*UserFavoriteMovie, error) {
+	o := &UserFavoriteMovie{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for user_favorite_movies")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+	o.Track()
+
+	return o, nil
+}
+
+// All returns all UserFavoriteMovie records from the query.
+func (q userFavoriteMovieQuery) All(ctx context.Context, exec boil.ContextExecutor) (UserFavoriteMovieSlice, error) {
+	var o []*UserFavoriteMovie
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to UserFavoriteMovie slice")
+	}
+
+	for _, obj := range o {
+		if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+			return o, err
+		}
+		obj.Track()
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all UserFavoriteMovie records in the query.
+func (q userFavoriteMovieQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count user_favorite_movies rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q userFavoriteMovieQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if user_favorite_movies exists")
+	}
+
+	return count > 0, nil
+}
+
+// Movie pointed to by the foreign key.
+func (o *UserFavoriteMovie) Movie(mods ...qm.QueryMod) movieQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.MovieID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Movies(queryMods...)
+}
+
+// User pointed to by the foreign key.
+func (o *UserFavoriteMovie) User(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// LoadMovie allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (userFavoriteMovieL) LoadMovie(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUserFavoriteMovie interface{}, mods queries.Applicator) error {
+	var slice []*UserFavoriteMovie
+	var object *UserFavoriteMovie
+
+	if singular {
+		var ok bool
+		object, ok = maybeUserFavoriteMovie.(*UserFavoriteMovie)
+		if !ok {
+			object = new(UserFavoriteMovie)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUserFavoriteMovie)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUserFavoriteMovie))
+			}
+		}
+	} else {
+		s, ok := maybeUserFavoriteMovie.(*[]*UserFavoriteMovie)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUserFavoriteMovie)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUserFavoriteMovie))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userFavoriteMovieR{}
+		}
+		args[object.MovieID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userFavoriteMovieR{}
+			}
+
+			args[obj.MovieID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`movies`),
+		qm.WhereIn(`movies.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Movie")
+	}
+
+	var resultSlice []*Movie
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Movie")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for movies")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for movies")
+	}
+
+	for _, obj := range resultSlice {
+		if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+			return err
+		}
+		obj.Track()
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Movie = foreign
+		if foreign.R == nil {
+			foreign.R = &movieR{}
+		}
+		foreign.R.UserFavoriteMovies = append(foreign.R.UserFavoriteMovies, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.MovieID == foreign.ID {
+				local.R.Movie = foreign
+				if foreign.R == nil {
+					foreign.R = &movieR{}
+				}
+				foreign.R.UserFavoriteMovies = append(foreign.R.UserFavoriteMovies, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (userFavoriteMovieL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUserFavoriteMovie interface{}, mods queries.Applicator) error {
+	var slice []*UserFavoriteMovie
+	var object *UserFavoriteMovie
+
+	if singular {
+		var ok bool
+		object, ok = maybeUserFavoriteMovie.(*UserFavoriteMovie)
+		if !ok {
+			object = new(UserFavoriteMovie)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUserFavoriteMovie)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUserFavoriteMovie))
+			}
+		}
+	} else {
+		s, ok := maybeUserFavoriteMovie.(*[]*UserFavoriteMovie)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUserFavoriteMovie)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUserFavoriteMovie))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userFavoriteMovieR{}
+		}
+		args[object.UserID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userFavoriteMovieR{}
+			}
+
+			args[obj.UserID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	for _, obj := range resultSlice {
+		if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+			return err
+		}
+		obj.Track()
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.UserFavoriteMovies = append(foreign.R.UserFavoriteMovies, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserID == foreign.ID {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.UserFavoriteMovies = append(foreign.R.UserFavoriteMovies, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetMovie of the userFavoriteMovie to the related item.
+// Sets o.R.Movie to related.
+// Adds o to related.R.UserFavoriteMovies.
+func (o *UserFavoriteMovie) SetMovie(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Movie) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"user_favorite_movies\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"movie_id"}),
+		strmangle.WhereClause("\"", "\"", 2, userFavoriteMoviePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.UserID, o.MovieID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.MovieID = related.ID
+	if o.R == nil {
+		o.R = &userFavoriteMovieR{
+			Movie: related,
+		}
+	} else {
+		o.R.Movie = related
+	}
+
+	if related.R == nil {
+		related.R = &movieR{
+			UserFavoriteMovies: UserFavoriteMovieSlice{o},
+		}
+	} else {
+		related.R.UserFavoriteMovies = append(related.R.UserFavoriteMovies, o)
+	}
+
+	return nil
+}
+
+// SetUser of the userFavoriteMovie to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.UserFavoriteMovies.
+func (o *UserFavoriteMovie) SetUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"user_favorite_movies\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, userFavoriteMoviePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.UserID, o.MovieID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.ID
+	if o.R == nil {
+		o.R = &userFavoriteMovieR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			UserFavoriteMovies: UserFavoriteMovieSlice{o},
+		}
+	} else {
+		related.R.UserFavoriteMovies = append(related.R.UserFavoriteMovies, o)
+	}
+
+	return nil
+}
+
+// UserFavoriteMovies retrieves all the records using an executor.
+func UserFavoriteMovies(mods ...qm.QueryMod) userFavoriteMovieQuery {
+	mods = append(mods, qm.From("\"user_favorite_movies\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"user_favorite_movies\".*"})
+	}
+
+	return userFavoriteMovieQuery{q}
+}
+
+// FindUserFavoriteMovie retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindUserFavoriteMovie(ctx context.Context, exec boil.ContextExecutor, userID int, movieID int, selectCols ...string) (*UserFavoriteMovie, error) {
+	userFavoriteMovieObj := &UserFavoriteMovie{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"user_favorite_movies\" where \"user_id\"=$1 AND \"movie_id\"=$2", sel,
+	)
+
+	q := queries.Raw(query, userID, movieID)
+
+	err := q.Bind(ctx, exec, userFavoriteMovieObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from user_favorite_movies")
+	}
+
+	if err = userFavoriteMovieObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return userFavoriteMovieObj, err
+	}
+	userFavoriteMovieObj.Track()
+
+	return userFavoriteMovieObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *UserFavoriteMovie) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no user_favorite_movies provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if queries.MustTime(o.CreatedAt).IsZero() {
+			queries.SetScanner(&o.CreatedAt, currTime)
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(userFavoriteMovieColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	userFavoriteMovieInsertCacheMut.RLock()
+	cache, cached := userFavoriteMovieInsertCache[key]
+	userFavoriteMovieInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			userFavoriteMovieAllColumns,
+			userFavoriteMovieColumnsWithDefault,
+			userFavoriteMovieColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(userFavoriteMovieType, userFavoriteMovieMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(userFavoriteMovieType, userFavoriteMovieMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"user_favorite_movies\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"user_favorite_movies\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into user_favorite_movies")
+	}
+
+	if !cached {
+		userFavoriteMovieInsertCacheMut.Lock()
+		userFavoriteMovieInsertCache[key] = cache
+		userFavoriteMovieInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the UserFavoriteMovie.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *UserFavoriteMovie) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	userFavoriteMovieUpdateCacheMut.RLock()
+	cache, cached := userFavoriteMovieUpdateCache[key]
+	userFavoriteMovieUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			userFavoriteMovieAllColumns,
+			userFavoriteMoviePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update user_favorite_movies, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"user_favorite_movies\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, userFavoriteMoviePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(userFavoriteMovieType, userFavoriteMovieMapping, append(wl, userFavoriteMoviePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update user_favorite_movies row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for user_favorite_movies")
+	}
+
+	if !cached {
+		userFavoriteMovieUpdateCacheMut.Lock()
+		userFavoriteMovieUpdateCache[key] = cache
+		userFavoriteMovieUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q userFavoriteMovieQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	op := &UserFavoriteMovieBulkOp{Query: q.Query, Cols: cols}
+	if err := doUserFavoriteMovieBulkHooks(ctx, exec, BeforeUpdateAllHook, op); err != nil {
+		return 0, err
+	}
+
+	queries.SetUpdate(q.Query, op.Cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for user_favorite_movies")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for user_favorite_movies")
+	}
+
+	op.RowsAffected = rowsAff
+	if err := doUserFavoriteMovieBulkHooks(ctx, exec, AfterUpdateAllHook, op); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o UserFavoriteMovieSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	op := &UserFavoriteMovieBulkOp{Slice: o, Cols: cols}
+	if err := doUserFavoriteMovieBulkHooks(ctx, exec, BeforeUpdateAllHook, op); err != nil {
+		return 0, err
+	}
+	cols = op.Cols
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userFavoriteMoviePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"user_favorite_movies\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, userFavoriteMoviePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in userFavoriteMovie slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all userFavoriteMovie")
+	}
+	op.RowsAffected = rowsAff
+	if err := doUserFavoriteMovieBulkHooks(ctx, exec, AfterUpdateAllHook, op); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+var userFavoriteMovieAllColumnsMapping, _ = queries.BindMapping(userFavoriteMovieType, userFavoriteMovieMapping, userFavoriteMovieAllColumns)
+
+// Track は現在の値をスナップショットにする。読み込んだ UserFavoriteMovie では自動で取るので、Insert の後などに追跡を始めるときに使う。
+func (o *UserFavoriteMovie) Track() {
+	o.snapshot = o.Copy()
+}
+
+// Snapshot は読み込んだ時点の値を返す。追跡していなければ ok は false になる。
+func (o *UserFavoriteMovie) Snapshot() (snapshot *UserFavoriteMovie, ok bool) {
+	if o.snapshot == nil {
+		return nil, false
+	}
+
+	return o.snapshot.Copy(), true
+}
+
+// Copy はカラムの値を複製した UserFavoriteMovie を返す。R（読み込んだリレーション）とスナップショットは複製しない。
+func (o *UserFavoriteMovie) Copy() *UserFavoriteMovie {
+	c := *o
+	c.R = nil
+	c.snapshot = nil
+
+	return &c
+}
+
+// DiffUserFavoriteMovies は a から b への変更をカラム順に返す
+func DiffUserFavoriteMovies(a, b *UserFavoriteMovie) []ColumnChange {
+	return diffColumns(userFavoriteMovieAllColumns,
+		queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(a)), userFavoriteMovieAllColumnsMapping),
+		queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(b)), userFavoriteMovieAllColumnsMapping))
+}
+
+// Changed は読み込んだ時点から変わったカラムを返す。追跡していなければ nil を返す。
+func (o *UserFavoriteMovie) Changed() []ColumnChange {
+	if o.snapshot == nil {
+		return nil
+	}
+
+	return DiffUserFavoriteMovies(o.snapshot, o)
+}
+
+// UpdateChanged は読み込んだ時点から変わったカラムだけを UPDATE する。
+// 変更が無ければ何もしない。追跡していなければ Update(boil.Infer()) と同じになる。
+// 列の組み合わせごとに Update と同じ userFavoriteMovieUpdateCache を使う。
+// BeforeUpdate フックが変えたカラムは書き込まれないので、そうしたカラムは Update で書く。
+func (o *UserFavoriteMovie) UpdateChanged(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o.snapshot == nil {
+		return o.Update(ctx, exec, boil.Infer())
+	}
+
+	cols, err := changedColumns("user_favorite_movies", DiffUserFavoriteMovies(o.snapshot, o), userFavoriteMoviePrimaryKeyColumns)
+	if err != nil || len(cols) == 0 {
+		return 0, err
+	}
+
+	rowsAff, err := o.Update(ctx, exec, boil.Whitelist(cols...))
+	if err != nil {
+		return rowsAff, err
+	}
+
+	o.Track()
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *UserFavoriteMovie) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no user_favorite_movies provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if queries.MustTime(o.CreatedAt).IsZero() {
+			queries.SetScanner(&o.CreatedAt, currTime)
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(userFavoriteMovieColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	userFavoriteMovieUpsertCacheMut.RLock()
+	cache, cached := userFavoriteMovieUpsertCache[key]
+	userFavoriteMovieUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			userFavoriteMovieAllColumns,
+			userFavoriteMovieColumnsWithDefault,
+			userFavoriteMovieColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			userFavoriteMovieAllColumns,
+			userFavoriteMoviePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert user_favorite_movies, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(userFavoriteMovieAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(userFavoriteMoviePrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert user_favorite_movies, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(userFavoriteMoviePrimaryKeyColumns))
+			copy(conflict, userFavoriteMoviePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"user_favorite_movies\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(userFavoriteMovieType, userFavoriteMovieMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(userFavoriteMovieType, userFavoriteMovieMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert user_favorite_movies")
+	}
+
+	if !cached {
+		userFavoriteMovieUpsertCacheMut.Lock()
+		userFavoriteMovieUpsertCache[key] = cache
+		userFavoriteMovieUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single UserFavoriteMovie record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *UserFavoriteMovie) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no UserFavoriteMovie provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), userFavoriteMoviePrimaryKeyMapping)
+	sql := "DELETE FROM \"user_favorite_movies\" WHERE \"user_id\"=$1 AND \"movie_id\"=$2"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from user_favorite_movies")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for user_favorite_movies")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q userFavoriteMovieQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no userFavoriteMovieQuery provided for delete all")
+	}
+
+	op := &UserFavoriteMovieBulkOp{Query: q.Query}
+	if err := doUserFavoriteMovieBulkHooks(ctx, exec, BeforeDeleteAllHook, op); err != nil {
+		return 0, err
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from user_favorite_movies")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for user_favorite_movies")
+	}
+
+	op.RowsAffected = rowsAff
+	if err := doUserFavoriteMovieBulkHooks(ctx, exec, AfterDeleteAllHook, op); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o UserFavoriteMovieSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	op := &UserFavoriteMovieBulkOp{Slice: o}
+	if err := doUserFavoriteMovieBulkHooks(ctx, exec, BeforeDeleteAllHook, op); err != nil {
+		return 0, err
+	}
+
+	for _, obj := range o {
+		if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+			return 0, err
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userFavoriteMoviePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"user_favorite_movies\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, userFavoriteMoviePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from userFavoriteMovie slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for user_favorite_movies")
+	}
+
+	for _, obj := range o {
+		if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+			return 0, err
+		}
+	}
+
+	op.RowsAffected = rowsAff
+	if err := doUserFavoriteMovieBulkHooks(ctx, exec, AfterDeleteAllHook, op); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *UserFavoriteMovie) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindUserFavoriteMovie(ctx, exec, o.UserID, o.MovieID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *UserFavoriteMovieSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := UserFavoriteMovieSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userFavoriteMoviePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"user_favorite_movies\".* FROM \"user_favorite_movies\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, userFavoriteMoviePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in UserFavoriteMovieSlice")
+	}
+	for _, obj := range slice {
+		obj.Track()
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// UserFavoriteMovieExists checks if the UserFavoriteMovie row exists.
+func UserFavoriteMovieExists(ctx context.Context, exec boil.ContextExecutor, userID int, movieID int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"user_favorite_movies\" where \"user_id\"=$1 AND \"movie_id\"=$2 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, userID, movieID)
+	}
+	row := exec.QueryRowContext(ctx, sql, userID, movieID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if user_favorite_movies exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the UserFavoriteMovie row exists.
+func (o *UserFavoriteMovie) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return UserFavoriteMovieExists(ctx, exec, o.UserID, o.MovieID)
+}
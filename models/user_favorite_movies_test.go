@@ -0,0 +1,984 @@
+// Code generated by SQLBoiler 4.18.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testUserFavoriteMovies(t *testing.T) {
+	t.Parallel()
+
+	query := UserFavoriteMovies()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testUserFavoriteMoviesDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserFavoriteMovie{}
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, true, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, o)
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := UserFavoriteMovies().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testUserFavoriteMoviesQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserFavoriteMovie{}
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, true, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, o)
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := UserFavoriteMovies().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := UserFavoriteMovies().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testUserFavoriteMoviesSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserFavoriteMovie{}
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, true, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, o)
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := UserFavoriteMovieSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := UserFavoriteMovies().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testUserFavoriteMoviesExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserFavoriteMovie{}
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, true, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, o)
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := UserFavoriteMovieExists(ctx, tx, o.UserID, o.MovieID)
+	if err != nil {
+		t.Errorf("Unable to check if UserFavoriteMovie exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected UserFavoriteMovieExists to return true, but got false.")
+	}
+}
+
+func testUserFavoriteMoviesFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserFavoriteMovie{}
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, true, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, o)
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	userFavoriteMovieFound, err := FindUserFavoriteMovie(ctx, tx, o.UserID, o.MovieID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if userFavoriteMovieFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testUserFavoriteMoviesBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserFavoriteMovie{}
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, true, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, o)
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = UserFavoriteMovies().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testUserFavoriteMoviesOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserFavoriteMovie{}
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, true, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, o)
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := UserFavoriteMovies().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testUserFavoriteMoviesAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	userFavoriteMovieOne := &UserFavoriteMovie{}
+	userFavoriteMovieTwo := &UserFavoriteMovie{}
+	if err = randomize.Struct(seed, userFavoriteMovieOne, userFavoriteMovieDBTypes, false, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+	if err = randomize.Struct(seed, userFavoriteMovieTwo, userFavoriteMovieDBTypes, false, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, userFavoriteMovieOne)
+	if err = userFavoriteMovieOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	insertUserFavoriteMovieParents(t, ctx, tx, userFavoriteMovieTwo)
+	if err = userFavoriteMovieTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := UserFavoriteMovies().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testUserFavoriteMoviesCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	userFavoriteMovieOne := &UserFavoriteMovie{}
+	userFavoriteMovieTwo := &UserFavoriteMovie{}
+	if err = randomize.Struct(seed, userFavoriteMovieOne, userFavoriteMovieDBTypes, false, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+	if err = randomize.Struct(seed, userFavoriteMovieTwo, userFavoriteMovieDBTypes, false, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, userFavoriteMovieOne)
+	if err = userFavoriteMovieOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	insertUserFavoriteMovieParents(t, ctx, tx, userFavoriteMovieTwo)
+	if err = userFavoriteMovieTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := UserFavoriteMovies().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func userFavoriteMovieBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *UserFavoriteMovie) error {
+	*o = UserFavoriteMovie{}
+	return nil
+}
+
+func userFavoriteMovieAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *UserFavoriteMovie) error {
+	*o = UserFavoriteMovie{}
+	return nil
+}
+
+func userFavoriteMovieAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *UserFavoriteMovie) error {
+	*o = UserFavoriteMovie{}
+	return nil
+}
+
+func userFavoriteMovieBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *UserFavoriteMovie) error {
+	*o = UserFavoriteMovie{}
+	return nil
+}
+
+func userFavoriteMovieAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *UserFavoriteMovie) error {
+	*o = UserFavoriteMovie{}
+	return nil
+}
+
+func userFavoriteMovieBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *UserFavoriteMovie) error {
+	*o = UserFavoriteMovie{}
+	return nil
+}
+
+func userFavoriteMovieAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *UserFavoriteMovie) error {
+	*o = UserFavoriteMovie{}
+	return nil
+}
+
+func userFavoriteMovieBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *UserFavoriteMovie) error {
+	*o = UserFavoriteMovie{}
+	return nil
+}
+
+func userFavoriteMovieAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *UserFavoriteMovie) error {
+	*o = UserFavoriteMovie{}
+	return nil
+}
+
+func testUserFavoriteMoviesHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &UserFavoriteMovie{}
+	o := &UserFavoriteMovie{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie object: %s", err)
+	}
+
+	AddUserFavoriteMovieHook(boil.BeforeInsertHook, userFavoriteMovieBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	userFavoriteMovieBeforeInsertHooks = []UserFavoriteMovieHook{}
+
+	AddUserFavoriteMovieHook(boil.AfterInsertHook, userFavoriteMovieAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	userFavoriteMovieAfterInsertHooks = []UserFavoriteMovieHook{}
+
+	AddUserFavoriteMovieHook(boil.AfterSelectHook, userFavoriteMovieAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	userFavoriteMovieAfterSelectHooks = []UserFavoriteMovieHook{}
+
+	AddUserFavoriteMovieHook(boil.BeforeUpdateHook, userFavoriteMovieBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	userFavoriteMovieBeforeUpdateHooks = []UserFavoriteMovieHook{}
+
+	AddUserFavoriteMovieHook(boil.AfterUpdateHook, userFavoriteMovieAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	userFavoriteMovieAfterUpdateHooks = []UserFavoriteMovieHook{}
+
+	AddUserFavoriteMovieHook(boil.BeforeDeleteHook, userFavoriteMovieBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	userFavoriteMovieBeforeDeleteHooks = []UserFavoriteMovieHook{}
+
+	AddUserFavoriteMovieHook(boil.AfterDeleteHook, userFavoriteMovieAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	userFavoriteMovieAfterDeleteHooks = []UserFavoriteMovieHook{}
+
+	AddUserFavoriteMovieHook(boil.BeforeUpsertHook, userFavoriteMovieBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	userFavoriteMovieBeforeUpsertHooks = []UserFavoriteMovieHook{}
+
+	AddUserFavoriteMovieHook(boil.AfterUpsertHook, userFavoriteMovieAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	userFavoriteMovieAfterUpsertHooks = []UserFavoriteMovieHook{}
+}
+
+func testUserFavoriteMoviesInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserFavoriteMovie{}
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, true, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, o)
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := UserFavoriteMovies().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testUserFavoriteMoviesInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserFavoriteMovie{}
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, o)
+	if err = o.Insert(ctx, tx, boil.Whitelist(userFavoriteMovieColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := UserFavoriteMovies().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testUserFavoriteMovieToOneMovieUsingMovie(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local UserFavoriteMovie
+	var foreign Movie
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, userFavoriteMovieDBTypes, false, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, movieDBTypes, false, movieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Movie struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	insertUserFavoriteMovieParents(t, ctx, tx, &local)
+	local.MovieID = foreign.ID
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.Movie().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	ranAfterSelectHook := false
+	AddMovieHook(boil.AfterSelectHook, func(ctx context.Context, e boil.ContextExecutor, o *Movie) error {
+		ranAfterSelectHook = true
+		return nil
+	})
+
+	slice := UserFavoriteMovieSlice{&local}
+	if err = local.L.LoadMovie(ctx, tx, false, (*[]*UserFavoriteMovie)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.Movie == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.Movie = nil
+	if err = local.L.LoadMovie(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.Movie == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	if !ranAfterSelectHook {
+		t.Error("failed to run AfterSelect hook for relationship")
+	}
+}
+
+func testUserFavoriteMovieToOneUserUsingUser(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local UserFavoriteMovie
+	var foreign User
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, userFavoriteMovieDBTypes, false, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, userDBTypes, false, userColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize User struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	insertUserFavoriteMovieParents(t, ctx, tx, &local)
+	local.UserID = foreign.ID
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.User().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	ranAfterSelectHook := false
+	AddUserHook(boil.AfterSelectHook, func(ctx context.Context, e boil.ContextExecutor, o *User) error {
+		ranAfterSelectHook = true
+		return nil
+	})
+
+	slice := UserFavoriteMovieSlice{&local}
+	if err = local.L.LoadUser(ctx, tx, false, (*[]*UserFavoriteMovie)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.User = nil
+	if err = local.L.LoadUser(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	if !ranAfterSelectHook {
+		t.Error("failed to run AfterSelect hook for relationship")
+	}
+}
+
+func testUserFavoriteMovieToOneSetOpMovieUsingMovie(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a UserFavoriteMovie
+	var b, c Movie
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, userFavoriteMovieDBTypes, false, strmangle.SetComplement(userFavoriteMoviePrimaryKeyColumns, userFavoriteMovieColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, movieDBTypes, false, strmangle.SetComplement(moviePrimaryKeyColumns, movieColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, movieDBTypes, false, strmangle.SetComplement(moviePrimaryKeyColumns, movieColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	insertUserFavoriteMovieParents(t, ctx, tx, &a)
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*Movie{&b, &c} {
+		err = a.SetMovie(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.Movie != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.UserFavoriteMovies[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.MovieID != x.ID {
+			t.Error("foreign key was wrong value", a.MovieID)
+		}
+
+		if exists, err := UserFavoriteMovieExists(ctx, tx, a.UserID, a.MovieID); err != nil {
+			t.Fatal(err)
+		} else if !exists {
+			t.Error("want 'a' to exist")
+		}
+
+	}
+}
+func testUserFavoriteMovieToOneSetOpUserUsingUser(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a UserFavoriteMovie
+	var b, c User
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, userFavoriteMovieDBTypes, false, strmangle.SetComplement(userFavoriteMoviePrimaryKeyColumns, userFavoriteMovieColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	insertUserFavoriteMovieParents(t, ctx, tx, &a)
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*User{&b, &c} {
+		err = a.SetUser(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.User != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.UserFavoriteMovies[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID)
+		}
+
+		if exists, err := UserFavoriteMovieExists(ctx, tx, a.UserID, a.MovieID); err != nil {
+			t.Fatal(err)
+		} else if !exists {
+			t.Error("want 'a' to exist")
+		}
+
+	}
+}
+
+func testUserFavoriteMoviesReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserFavoriteMovie{}
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, true, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, o)
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testUserFavoriteMoviesReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserFavoriteMovie{}
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, true, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, o)
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := UserFavoriteMovieSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testUserFavoriteMoviesSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserFavoriteMovie{}
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, true, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, o)
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := UserFavoriteMovies().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	userFavoriteMovieDBTypes = map[string]string{`UserID`: `integer`, `MovieID`: `integer`, `CreatedAt`: `timestamp without time zone`}
+	_                        = bytes.MinRead
+)
+
+func testUserFavoriteMoviesUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(userFavoriteMoviePrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(userFavoriteMovieAllColumns) == len(userFavoriteMoviePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserFavoriteMovie{}
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, true, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, o)
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := UserFavoriteMovies().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, true, userFavoriteMoviePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testUserFavoriteMoviesSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(userFavoriteMovieAllColumns) == len(userFavoriteMoviePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserFavoriteMovie{}
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, true, userFavoriteMovieColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, o)
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := UserFavoriteMovies().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, userFavoriteMovieDBTypes, true, userFavoriteMoviePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(userFavoriteMovieAllColumns, userFavoriteMoviePrimaryKeyColumns) {
+		fields = userFavoriteMovieAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			userFavoriteMovieAllColumns,
+			userFavoriteMoviePrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := UserFavoriteMovieSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testUserFavoriteMoviesUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(userFavoriteMovieAllColumns) == len(userFavoriteMoviePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := UserFavoriteMovie{}
+	if err = randomize.Struct(seed, &o, userFavoriteMovieDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	insertUserFavoriteMovieParents(t, ctx, tx, &o)
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert UserFavoriteMovie: %s", err)
+	}
+
+	count, err := UserFavoriteMovies().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, userFavoriteMovieDBTypes, false, userFavoriteMoviePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize UserFavoriteMovie struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert UserFavoriteMovie: %s", err)
+	}
+
+	count, err = UserFavoriteMovies().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -0,0 +1,234 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// user_favorite_movies は created_at を持つため sqlboiler は多対多の FavoriteMovies を生成しない。
+// ここでは中間テーブルの created_at（お気に入りに登録した日時）を残したまま映画を扱う。
+
+// FavoriteMovie はお気に入りに登録した日時付きの映画
+type FavoriteMovie struct {
+	Movie       `boil:",bind"`
+	FavoritedAt null.Time `boil:"favorited_at" json:"favorited_at,omitempty" toml:"favorited_at" yaml:"favorited_at,omitempty"`
+}
+
+// FavoriteMovieSlice is an alias for a slice of pointers to FavoriteMovie.
+type FavoriteMovieSlice []*FavoriteMovie
+
+// MovieFavoriteCount はお気に入りに登録したユーザー数付きの映画
+type MovieFavoriteCount struct {
+	Movie         `boil:",bind"`
+	FavoriteCount int64 `boil:"favorite_count" json:"favorite_count" toml:"favorite_count" yaml:"favorite_count"`
+}
+
+// MovieFavoriteCountSlice is an alias for a slice of pointers to MovieFavoriteCount.
+type MovieFavoriteCountSlice []*MovieFavoriteCount
+
+type favoriteMovieQuery struct {
+	*queries.Query
+}
+
+type movieFavoriteCountQuery struct {
+	*queries.Query
+}
+
+const favoriteMoviesJoin = "\"user_favorite_movies\" on \"user_favorite_movies\".\"movie_id\" = \"movies\".\"id\""
+
+// FavoritedAtAsc はお気に入りに登録した日時の古い順に並べる
+var FavoritedAtAsc = qm.OrderBy("\"user_favorite_movies\".\"created_at\" ASC, \"movies\".\"id\" ASC")
+
+// FavoritedAtDesc はお気に入りに登録した日時の新しい順に並べる
+var FavoritedAtDesc = qm.OrderBy("\"user_favorite_movies\".\"created_at\" DESC, \"movies\".\"id\" DESC")
+
+type pageQueryMod struct {
+	limit  int
+	offset int
+}
+
+// Apply implements qm.QueryMod.Apply.
+func (m pageQueryMod) Apply(q *queries.Query) {
+	queries.SetLimit(q, m.limit)
+	queries.SetOffset(q, m.offset)
+}
+
+// Page は 1 始まりの page 番目を perPage 件ずつ取得する
+func Page(page, perPage int) qm.QueryMod {
+	if page < 1 {
+		page = 1
+	}
+
+	return pageQueryMod{limit: perPage, offset: (page - 1) * perPage}
+}
+
+// FavoriteMovies は o のお気に入りの映画を登録日時付きで取得するクエリ。
+// 並び順は FavoritedAtAsc / FavoritedAtDesc で指定する。
+func (o *User) FavoriteMovies(mods ...qm.QueryMod) favoriteMovieQuery {
+	queryMods := []qm.QueryMod{
+		qm.Select("\"movies\".*", "\"user_favorite_movies\".\"created_at\" as \"favorited_at\""),
+		qm.InnerJoin(favoriteMoviesJoin),
+		qm.Where("\"user_favorite_movies\".\"user_id\"=?", o.ID),
+	}
+	queryMods = append(queryMods, mods...)
+
+	q := NewQuery(queryMods...)
+	queries.SetFrom(q, "\"movies\"")
+
+	return favoriteMovieQuery{q}
+}
+
+// All returns all FavoriteMovie records from the query.
+func (q favoriteMovieQuery) All(ctx context.Context, exec boil.ContextExecutor) (FavoriteMovieSlice, error) {
+	var o []*FavoriteMovie
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to FavoriteMovie slice")
+	}
+
+	for _, obj := range o {
+		if err := obj.Movie.doAfterSelectHooks(ctx, exec); err != nil {
+			return o, err
+		}
+		obj.Movie.Track()
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all FavoriteMovie records in the query.
+// 生成コードの Count と同様に、並び順の mod を付けたクエリでは使えない。
+func (q favoriteMovieQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count favorite movies")
+	}
+
+	return count, nil
+}
+
+// AddFavorite は movieID を o のお気に入りに追加する。
+// 既に追加済みなら何もせず（ON CONFLICT DO NOTHING）、added は false になる。
+func (o *User) AddFavorite(ctx context.Context, exec boil.ContextExecutor, movieID int) (added bool, err error) {
+	fav := &UserFavoriteMovie{UserID: o.ID, MovieID: movieID}
+	if !boil.TimestampsAreSkipped(ctx) {
+		queries.SetScanner(&fav.CreatedAt, time.Now().In(boil.GetLocation()))
+	}
+
+	if err := fav.doBeforeInsertHooks(ctx, exec); err != nil {
+		return false, err
+	}
+
+	query := "INSERT INTO \"user_favorite_movies\" (\"user_id\",\"movie_id\",\"created_at\") VALUES ($1,$2,$3) ON CONFLICT (\"user_id\",\"movie_id\") DO NOTHING"
+	vals := []interface{}{fav.UserID, fav.MovieID, fav.CreatedAt}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, vals...)
+	}
+	result, err := exec.ExecContext(ctx, query, vals...)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to add favorite movie")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to get rows affected by add favorite movie")
+	}
+	if rowsAff == 0 {
+		return false, nil
+	}
+
+	return true, fav.doAfterInsertHooks(ctx, exec)
+}
+
+// RemoveFavorite は movieID を o のお気に入りから外す。登録されていなければ removed は false になる。
+func (o *User) RemoveFavorite(ctx context.Context, exec boil.ContextExecutor, movieID int) (removed bool, err error) {
+	fav, err := FindUserFavoriteMovie(ctx, exec, o.ID, movieID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	rowsAff, err := fav.Delete(ctx, exec)
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAff != 0, nil
+}
+
+// MostFavoritedMovies はお気に入りに登録したユーザーの多い順に映画を取得するクエリ。
+// 同数の場合は id 順。ページ分けには Page を使う。
+func MostFavoritedMovies(mods ...qm.QueryMod) movieFavoriteCountQuery {
+	queryMods := []qm.QueryMod{
+		qm.Select("\"movies\".*", "count(\"user_favorite_movies\".\"user_id\") as \"favorite_count\""),
+		qm.InnerJoin(favoriteMoviesJoin),
+		qm.GroupBy("\"movies\".\"id\""),
+		qm.OrderBy("\"favorite_count\" DESC, \"movies\".\"id\" ASC"),
+	}
+	queryMods = append(queryMods, mods...)
+
+	q := NewQuery(queryMods...)
+	queries.SetFrom(q, "\"movies\"")
+
+	return movieFavoriteCountQuery{q}
+}
+
+// All returns all MovieFavoriteCount records from the query.
+func (q movieFavoriteCountQuery) All(ctx context.Context, exec boil.ContextExecutor) (MovieFavoriteCountSlice, error) {
+	var o []*MovieFavoriteCount
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to MovieFavoriteCount slice")
+	}
+
+	for _, obj := range o {
+		if err := obj.Movie.doAfterSelectHooks(ctx, exec); err != nil {
+			return o, err
+		}
+		obj.Movie.Track()
+	}
+
+	return o, nil
+}
+
+// CountFavoritedMovies は 1 人以上がお気に入りに登録している映画の数を返す。
+// MostFavoritedMovies をページ分けするときの総件数に使う。mods には絞り込みの条件だけを渡す。
+func CountFavoritedMovies(ctx context.Context, exec boil.ContextExecutor, mods ...qm.QueryMod) (int64, error) {
+	var count int64
+
+	queryMods := []qm.QueryMod{
+		qm.Select("count(distinct \"movies\".\"id\")"),
+		qm.InnerJoin(favoriteMoviesJoin),
+	}
+	queryMods = append(queryMods, mods...)
+
+	q := NewQuery(queryMods...)
+	queries.SetFrom(q, "\"movies\"")
+
+	err := q.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count favorited movies")
+	}
+
+	return count, nil
+}
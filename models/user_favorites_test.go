@@ -0,0 +1,216 @@
+package models
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+func TestFavoriteMoviesQuery(t *testing.T) {
+	t.Parallel()
+
+	u := &User{ID: 7}
+	sql, args := queries.BuildQuery(u.FavoriteMovies(FavoritedAtDesc, Page(3, 10)).Query)
+
+	want := `SELECT "movies".*, "user_favorite_movies"."created_at" as "favorited_at" FROM "movies" ` +
+		`INNER JOIN "user_favorite_movies" on "user_favorite_movies"."movie_id" = "movies"."id" ` +
+		`WHERE ("user_favorite_movies"."user_id"=$1) ` +
+		`ORDER BY "user_favorite_movies"."created_at" DESC, "movies"."id" DESC LIMIT 10 OFFSET 20;`
+	if sql != want {
+		t.Errorf("got:\n%s\nwant:\n%s", sql, want)
+	}
+	if len(args) != 1 || args[0] != 7 {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestMostFavoritedMoviesQuery(t *testing.T) {
+	t.Parallel()
+
+	sql, _ := queries.BuildQuery(MostFavoritedMovies(Page(1, 5)).Query)
+
+	for _, part := range []string{
+		`count("user_favorite_movies"."user_id") as "favorite_count"`,
+		`GROUP BY "movies"."id"`,
+		`ORDER BY "favorite_count" DESC, "movies"."id" ASC`,
+		`LIMIT 5`,
+	} {
+		if !strings.Contains(sql, part) {
+			t.Errorf("%q not found in:\n%s", part, sql)
+		}
+	}
+	if strings.Contains(sql, "OFFSET") {
+		t.Errorf("first page should not have an offset:\n%s", sql)
+	}
+}
+
+func insertFavoriteFixtures(t *testing.T, ctx context.Context, exec boil.ContextExecutor, movies int) (*User, MovieSlice) {
+	t.Helper()
+
+	seed := randomize.NewSeed()
+	u := &User{}
+	if err := randomize.Struct(seed, u, userDBTypes, true, userColumnsWithDefault...); err != nil {
+		t.Fatalf("Unable to randomize User struct: %s", err)
+	}
+	if err := u.Insert(ctx, exec, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	var ms MovieSlice
+	for i := 0; i < movies; i++ {
+		m := &Movie{}
+		if err := randomize.Struct(seed, m, movieDBTypes, true, movieColumnsWithDefault...); err != nil {
+			t.Fatalf("Unable to randomize Movie struct: %s", err)
+		}
+		if err := m.Insert(ctx, exec, boil.Infer()); err != nil {
+			t.Fatal(err)
+		}
+		ms = append(ms, m)
+	}
+
+	return u, ms
+}
+
+func TestUserAddFavoriteIdempotent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	u, movies := insertFavoriteFixtures(t, ctx, tx, 1)
+
+	added, err := u.AddFavorite(ctx, tx, movies[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !added {
+		t.Error("first add should report added")
+	}
+
+	added, err = u.AddFavorite(ctx, tx, movies[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added {
+		t.Error("second add should be a no-op")
+	}
+
+	count, err := u.FavoriteMovies().Count(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("want 1 favorite, got %d", count)
+	}
+
+	removed, err := u.RemoveFavorite(ctx, tx, movies[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !removed {
+		t.Error("remove should report removed")
+	}
+
+	removed, err = u.RemoveFavorite(ctx, tx, movies[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed {
+		t.Error("second remove should be a no-op")
+	}
+}
+
+func TestUserFavoriteMoviesOrderedByFavoritedAt(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	u, movies := insertFavoriteFixtures(t, ctx, tx, 3)
+
+	// 登録日時を明示して、id の順とは逆にお気に入りに追加する
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for i, m := range movies {
+		fav := &UserFavoriteMovie{UserID: u.ID, MovieID: m.ID}
+		queries.SetScanner(&fav.CreatedAt, base.Add(-time.Duration(i)*time.Minute))
+		if err := fav.Insert(ctx, tx, boil.Infer()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	favs, err := u.FavoriteMovies(FavoritedAtAsc).All(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(favs) != len(movies) {
+		t.Fatalf("want %d favorites, got %d", len(movies), len(favs))
+	}
+	for i, f := range favs {
+		want := movies[len(movies)-1-i]
+		if f.ID != want.ID {
+			t.Errorf("favs[%d] = movie %d, want %d", i, f.ID, want.ID)
+		}
+		if !f.FavoritedAt.Valid {
+			t.Errorf("favs[%d] has no favorited_at", i)
+		}
+	}
+}
+
+func TestMostFavoritedMovies(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	a, movies := insertFavoriteFixtures(t, ctx, tx, 2)
+	b, _ := insertFavoriteFixtures(t, ctx, tx, 0)
+
+	for _, u := range []*User{a, b} {
+		if _, err := u.AddFavorite(ctx, tx, movies[1].ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := a.AddFavorite(ctx, tx, movies[0].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []int{movies[0].ID, movies[1].ID}
+	counts, err := MostFavoritedMovies(MovieWhere.ID.IN(ids), Page(1, 10)).All(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 2 {
+		t.Fatalf("want 2 movies, got %d", len(counts))
+	}
+	if counts[0].ID != movies[1].ID || counts[0].FavoriteCount != 2 {
+		t.Errorf("first = movie %d (%d), want movie %d (2)", counts[0].ID, counts[0].FavoriteCount, movies[1].ID)
+	}
+	if counts[1].FavoriteCount != 1 {
+		t.Errorf("second count = %d, want 1", counts[1].FavoriteCount)
+	}
+
+	second, err := MostFavoritedMovies(MovieWhere.ID.IN(ids), Page(2, 1)).All(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second) != 1 || second[0].ID != movies[0].ID {
+		t.Errorf("page 2 = %v, want movie %d", second, movies[0].ID)
+	}
+
+	total, err := CountFavoritedMovies(ctx, tx, qm.WhereIn("\"movies\".\"id\" in ?", ids[0], ids[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 {
+		t.Errorf("want 2 favorited movies, got %d", total)
+	}
+}
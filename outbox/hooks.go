@@ -0,0 +1,45 @@
+package outbox
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"sqlboiler-project/models"
+)
+
+// Book のイベント種別
+const (
+	BookCreated = "book.created"
+	BookUpdated = "book.updated"
+	BookDeleted = "book.deleted"
+)
+
+// RegisterBookHooks は Book の Insert/Update/Delete 後に outbox へイベントを積むフックを登録し、登録を外す関数を返す。
+// フックに渡される exec をそのまま使うので、トランザクション内の変更なら同じトランザクションに書き込まれる。
+// フックの名前はイベント種別と同じなので、models.SkipHooks(ctx, BookUpdated) のように種別ごとに止められる。
+func RegisterBookHooks() (unregister func()) {
+	unregisters := []func(){
+		models.RegisterBookHook(boil.AfterInsertHook, bookHook(BookCreated), models.HookName(BookCreated)),
+		models.RegisterBookHook(boil.AfterUpdateHook, bookHook(BookUpdated), models.HookName(BookUpdated)),
+		models.RegisterBookHook(boil.AfterDeleteHook, bookHook(BookDeleted), models.HookName(BookDeleted)),
+	}
+
+	return func() {
+		for _, u := range unregisters {
+			u()
+		}
+	}
+}
+
+func bookHook(eventType string) models.BookHook {
+	return func(ctx context.Context, exec boil.ContextExecutor, b *models.Book) error {
+		return Enqueue(ctx, exec, Event{
+			AggregateType: models.TableNames.Books,
+			AggregateID:   strconv.Itoa(b.ID),
+			EventType:     eventType,
+			Payload:       b,
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Package outbox はモデルの変更と同じトランザクションでイベントを outbox_events に書き込み、
+// リレーワーカーから外部へ配信するためのパッケージ。
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"sqlboiler-project/models"
+)
+
+// Event は outbox に積むイベント
+type Event struct {
+	AggregateType string
+	AggregateID   string
+	EventType     string
+	Payload       interface{}
+}
+
+// Message は Sink に渡される配信単位
+type Message struct {
+	ID            int64           `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	Attempt       int             `json:"attempt"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// Enqueue はイベントを outbox_events に書き込む。
+// モデルの変更と同じ exec（トランザクション）を渡すことで、ロールバック時にはイベントも残らない。
+func Enqueue(ctx context.Context, exec boil.ContextExecutor, e Event) error {
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		return errors.Wrap(err, "outbox: unable to marshal event payload")
+	}
+
+	o := &models.OutboxEvent{
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		EventType:     e.EventType,
+		Payload:       types.JSON(payload),
+		NextAttemptAt: time.Now().In(boil.GetLocation()),
+	}
+	if err := o.Insert(ctx, exec, boil.Infer()); err != nil {
+		return errors.Wrap(err, "outbox: unable to enqueue event")
+	}
+
+	return nil
+}
+
+func newMessage(o *models.OutboxEvent) Message {
+	m := Message{
+		ID:            o.ID,
+		AggregateType: o.AggregateType,
+		AggregateID:   o.AggregateID,
+		EventType:     o.EventType,
+		Payload:       json.RawMessage(o.Payload),
+		Attempt:       o.Attempts + 1,
+	}
+	if o.CreatedAt.Valid {
+		m.CreatedAt = o.CreatedAt.Time
+	}
+
+	return m
+}
@@ -0,0 +1,230 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+
+	"sqlboiler-project/internal/testdb"
+	"sqlboiler-project/models"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	t.Parallel()
+
+	backoff := ExponentialBackoff(time.Second, 10*time.Second)
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, w := range want {
+		if got := backoff(i + 1); got != w {
+			t.Errorf("attempt %d: want %s, got %s", i+1, w, got)
+		}
+	}
+}
+
+func TestRelayClaimQuery(t *testing.T) {
+	t.Parallel()
+
+	r := NewRelay(nil, &MemorySink{})
+	sql, args := queries.BuildQuery(models.OutboxEvents(r.claimMods(time.Now())...).Query)
+
+	if !strings.HasSuffix(strings.TrimSpace(sql), "FOR UPDATE SKIP LOCKED;") {
+		t.Errorf("claim query must lock rows with SKIP LOCKED: %s", sql)
+	}
+	if !strings.Contains(sql, `"outbox_events"."sent_at" is null`) {
+		t.Errorf("claim query must skip sent events: %s", sql)
+	}
+	if len(args) != 2 {
+		t.Errorf("want 2 args, got %d", len(args))
+	}
+}
+
+func TestMemorySink(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	failure := errors.New("boom")
+	s := &MemorySink{Fail: func(m Message) error {
+		if m.Attempt == 1 {
+			return failure
+		}
+		return nil
+	}}
+
+	if err := s.Deliver(ctx, Message{ID: 1, Attempt: 1}); !errors.Is(err, failure) {
+		t.Errorf("want injected failure, got %v", err)
+	}
+	if err := s.Deliver(ctx, Message{ID: 1, Attempt: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs := s.Messages()
+	if len(msgs) != 1 || msgs[0].Attempt != 2 {
+		t.Errorf("want only the successful delivery recorded, got %+v", msgs)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	s, err := NewFileSink(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for i := int64(1); i <= 2; i++ {
+		m := Message{ID: i, EventType: BookCreated, Payload: json.RawMessage(`{"id":1}`)}
+		if err := s.Deliver(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var ids []int64
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var m Message
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, m.ID)
+	}
+
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("want ids [1 2], got %v", ids)
+	}
+}
+
+// ロールバックしたトランザクションのイベントは残らず、コミットしたものだけが配信される
+func TestEnqueueRollback(t *testing.T) {
+	// フックはパッケージ全体に効くので、このテストは並行に走らせない
+	db := testdb.Open(t)
+	ctx := context.Background()
+	defer RegisterBookHooks()()
+
+	write := func(title string) *sql.Tx {
+		t.Helper()
+
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := &models.Book{Title: title, AuthorName: "Ursula K. Le Guin"}
+		if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+			t.Fatal(err)
+		}
+		if err = Enqueue(ctx, tx, Event{AggregateType: "test", AggregateID: title, EventType: "test.written"}); err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+
+	tx := write("The Dispossessed")
+	if n, err := models.OutboxEvents().Count(ctx, tx); err != nil || n != 2 {
+		t.Fatalf("want 2 events inside the transaction, got %d (%v)", n, err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := models.OutboxEvents().Count(ctx, db); err != nil || n != 0 {
+		t.Fatalf("rolled back events remain: %d (%v)", n, err)
+	}
+
+	if err := write("The Lathe of Heaven").Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	sink := &MemorySink{}
+	if _, err := NewRelay(db, sink).ProcessBatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, m := range sink.Messages() {
+		got = append(got, m.EventType)
+	}
+	if strings.Join(got, ",") != BookCreated+",test.written" {
+		t.Errorf("unexpected messages %v", got)
+	}
+}
+
+// SKIP LOCKED で確保するので、同時に動く 2 つのリレーが同じイベントを配信することはない
+func TestRelaysDoNotDeliverTwice(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.Open(t)
+	ctx := context.Background()
+
+	const events = 60
+	for i := 0; i < events; i++ {
+		if err := Enqueue(ctx, db, Event{AggregateType: "test", AggregateID: strconv.Itoa(i), EventType: "test.created"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 配信に時間をかけて、確保したロックを保持したままもう一方のリレーが走るようにする
+	slow := func(Message) error {
+		time.Sleep(2 * time.Millisecond)
+		return nil
+	}
+	sinks := []*MemorySink{{Fail: slow}, {Fail: slow}}
+
+	var wg sync.WaitGroup
+	for _, sink := range sinks {
+		r := NewRelay(db, sink)
+		r.BatchSize = 5
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				n, err := r.ProcessBatch(ctx)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if n == 0 {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := map[int64]bool{}
+	for _, sink := range sinks {
+		for _, m := range sink.Messages() {
+			if seen[m.ID] {
+				t.Errorf("event %d delivered twice", m.ID)
+			}
+			seen[m.ID] = true
+		}
+	}
+	if len(seen) != events {
+		t.Errorf("want %d events delivered, got %d", events, len(seen))
+	}
+
+	if n, err := models.OutboxEvents(models.OutboxEventWhere.SentAt.IsNull()).Count(ctx, db); err != nil || n != 0 {
+		t.Errorf("%d events left unsent (%v)", n, err)
+	}
+}
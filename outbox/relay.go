@@ -0,0 +1,146 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"sqlboiler-project/models"
+)
+
+// Relay は未送信のイベントを取り出して Sink に配信するワーカー。
+// 行は SELECT ... FOR UPDATE SKIP LOCKED で確保するので、複数のリレーを同時に動かしても
+// 同じイベントを二重に処理しない。配信はコミット前に行うため at-least-once になる。
+type Relay struct {
+	// BatchSize は 1 トランザクションで確保するイベント数
+	BatchSize int
+	// MaxAttempts を超えて失敗したイベントは再送しない
+	MaxAttempts int
+	// PollInterval は未送信イベントが無かったときの待ち時間
+	PollInterval time.Duration
+	// Backoff は失敗回数から次の再送までの待ち時間を返す
+	Backoff func(attempts int) time.Duration
+
+	db   *sql.DB
+	sink Sink
+}
+
+// NewRelay はデフォルト設定の Relay を返す。
+// outbox_events は RLS でテナントごとに絞り込まれるので、db は RLS を素通りするロールで開く
+// （psql.role を空にしてスーパーユーザーか BYPASSRLS のユーザーで接続する）。
+func NewRelay(db *sql.DB, sink Sink) *Relay {
+	return &Relay{
+		BatchSize:    100,
+		MaxAttempts:  10,
+		PollInterval: time.Second,
+		Backoff:      ExponentialBackoff(time.Second, 5*time.Minute),
+		db:           db,
+		sink:         sink,
+	}
+}
+
+// ExponentialBackoff は base から倍々に増え max で頭打ちになる待ち時間を返す関数を作る
+func ExponentialBackoff(base, max time.Duration) func(attempts int) time.Duration {
+	return func(attempts int) time.Duration {
+		d := base
+		for i := 1; i < attempts; i++ {
+			d *= 2
+			if d >= max {
+				return max
+			}
+		}
+		return d
+	}
+}
+
+// Run は ctx がキャンセルされるまでイベントの配信を繰り返す
+func (r *Relay) Run(ctx context.Context) error {
+	for {
+		n, err := r.ProcessBatch(ctx)
+		if err != nil {
+			log.Printf("アウトボックス配信エラー: %v\n", err)
+		}
+
+		if n == 0 || err != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(r.PollInterval):
+			}
+		} else if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// ProcessBatch は配信可能なイベントを最大 BatchSize 件確保して配信し、処理した件数を返す。
+// 配信に成功したイベントは sent_at を、失敗したイベントは attempts と次回の再送時刻を更新する。
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "outbox: unable to begin relay transaction")
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().In(boil.GetLocation())
+
+	events, err := models.OutboxEvents(r.claimMods(now)...).All(ctx, tx)
+	if err != nil {
+		return 0, errors.Wrap(err, "outbox: unable to claim events")
+	}
+
+	for _, e := range events {
+		if err := r.deliver(ctx, tx, e); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errors.Wrap(err, "outbox: unable to commit relay transaction")
+	}
+
+	return len(events), nil
+}
+
+// claimMods は配信対象のイベントを他のリレーと取り合わずに確保するクエリ修飾子
+func (r *Relay) claimMods(now time.Time) []qm.QueryMod {
+	return []qm.QueryMod{
+		models.OutboxEventWhere.SentAt.IsNull(),
+		models.OutboxEventWhere.Attempts.LT(r.MaxAttempts),
+		models.OutboxEventWhere.NextAttemptAt.LTE(now),
+		qm.OrderBy(models.OutboxEventColumns.ID),
+		qm.Limit(r.BatchSize),
+		models.ForUpdate().SkipLocked(),
+	}
+}
+
+func (r *Relay) deliver(ctx context.Context, exec boil.ContextExecutor, e *models.OutboxEvent) error {
+	now := time.Now().In(boil.GetLocation())
+
+	var cols boil.Columns
+	if err := r.sink.Deliver(ctx, newMessage(e)); err != nil {
+		e.Attempts++
+		e.LastError = null.StringFrom(err.Error())
+		e.NextAttemptAt = now.Add(r.Backoff(e.Attempts))
+		cols = boil.Whitelist(
+			models.OutboxEventColumns.Attempts,
+			models.OutboxEventColumns.LastError,
+			models.OutboxEventColumns.NextAttemptAt,
+		)
+	} else {
+		e.SentAt = null.TimeFrom(now)
+		cols = boil.Whitelist(models.OutboxEventColumns.SentAt)
+	}
+
+	if _, err := e.Update(ctx, exec, cols); err != nil {
+		return errors.Wrapf(err, "outbox: unable to update event %d", e.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/friendsofgo/errors"
+)
+
+// Sink はリレーがメッセージを届ける先
+type Sink interface {
+	Deliver(ctx context.Context, m Message) error
+}
+
+// MemorySink は受け取ったメッセージをメモリに保持する Sink（テスト用）
+type MemorySink struct {
+	// Fail が nil でなければ配信のたびに呼ばれ、エラーを返すと配信失敗として扱う
+	Fail func(Message) error
+
+	mu       sync.Mutex
+	messages []Message
+}
+
+// Deliver はメッセージを記録する
+func (s *MemorySink) Deliver(ctx context.Context, m Message) error {
+	if s.Fail != nil {
+		if err := s.Fail(m); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	s.messages = append(s.messages, m)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Messages はこれまでに配信されたメッセージのコピーを返す
+func (s *MemorySink) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ret := make([]Message, len(s.messages))
+	copy(ret, s.messages)
+	return ret
+}
+
+// FileSink はメッセージを JSON Lines 形式でファイルに追記する Sink
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// NewFileSink は path を追記モードで開いた FileSink を返す
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, errors.Wrap(err, "outbox: unable to open file sink")
+	}
+
+	return &FileSink{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+// Deliver はメッセージを 1 行の JSON として書き込み、ディスクに同期する
+func (s *FileSink) Deliver(ctx context.Context, m Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.enc.Encode(m); err != nil {
+		return errors.Wrap(err, "outbox: unable to write message to file sink")
+	}
+
+	return s.file.Sync()
+}
+
+// Close はファイルを閉じる
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
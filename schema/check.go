@@ -0,0 +1,200 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/drivers/sqlboiler-psql/driver"
+	"github.com/volatiletech/strmangle"
+
+	"sqlboiler-project/models"
+)
+
+// Diff はモデルとデータベースの食い違い 1 件。
+// Model / Database はそれぞれの側の状態で、片方にしか無いものは空文字になる。
+type Diff struct {
+	Table    string
+	Column   string
+	Field    string
+	Model    string
+	Database string
+}
+
+// String は diff 風の 1 行表現を返す
+func (d Diff) String() string {
+	if d.Field == fieldGenerator {
+		return fmt.Sprintf("~ generated by %s, go.mod requires %s (regenerate the models or align go.mod)", d.Model, d.Database)
+	}
+
+	subject := "table " + d.Table
+	if len(d.Column) != 0 {
+		subject = "column " + d.Column
+	}
+	if len(d.Field) != 0 {
+		subject += " " + d.Field
+	}
+
+	switch {
+	case len(d.Database) == 0:
+		return fmt.Sprintf("- %s: %s (missing in database)", subject, d.Model)
+	case len(d.Model) == 0:
+		return fmt.Sprintf("+ %s: %s (missing in models)", subject, d.Database)
+	default:
+		return fmt.Sprintf("~ %s: models %s, database %s", subject, d.Model, d.Database)
+	}
+}
+
+// Check は schemaName の実スキーマと models のメタデータを比較し、食い違いを返す。
+// ignored にはモデルを生成しないテーブル（sqlboiler.toml の blacklist）を渡す。
+func Check(ctx context.Context, exec boil.ContextExecutor, schemaName string, ignored []string) ([]Diff, error) {
+	tables, err := Introspect(ctx, exec, schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	return Compare(models.TableNameList(), models.TableSchemas, tables, ignored), nil
+}
+
+// Compare は TableNames・モデルのメタデータ・実スキーマの三者を比較する
+func Compare(tableNames []string, schemas []models.TableSchema, tables map[string]*Table, ignored []string) []Diff {
+	var diffs []Diff
+
+	modelSchemas := map[string]models.TableSchema{}
+	for _, s := range schemas {
+		modelSchemas[s.Name] = s
+	}
+
+	for _, name := range tableNames {
+		t, inDB := tables[name]
+		s, hasMeta := modelSchemas[name]
+
+		switch {
+		case !hasMeta:
+			diffs = append(diffs, Diff{Table: name, Model: "no metadata in models.TableSchemas", Database: presence(inDB)})
+		case !inDB:
+			diffs = append(diffs, Diff{Table: name, Model: "present"})
+		default:
+			diffs = append(diffs, compareTable(s, t)...)
+		}
+	}
+
+	var extra []string
+	for name := range tables {
+		if !contains(tableNames, name) && !contains(ignored, name) {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		diffs = append(diffs, Diff{Table: name, Database: "present"})
+	}
+
+	return diffs
+}
+
+func compareTable(s models.TableSchema, t *Table) []Diff {
+	var diffs []Diff
+	pg := &driver.PostgresDriver{}
+
+	for _, name := range s.Columns {
+		c, ok := t.Column(name)
+		if !ok {
+			goType, _ := s.ColumnType(name)
+			diffs = append(diffs, Diff{Table: t.Name, Column: name, Model: goType})
+			continue
+		}
+
+		// 生成時と同じ変換で DB の型から Go の型を求めて比較する
+		want := pg.TranslateColumnType(drivers.Column{
+			Name:     c.Name,
+			DBType:   c.DataType,
+			UDTName:  c.UDTName,
+			Nullable: c.Nullable,
+		}).Type
+		if got, _ := s.ColumnType(name); got != want {
+			diffs = append(diffs, Diff{Table: t.Name, Column: name, Field: "type", Model: got, Database: fmt.Sprintf("%s (%s)", want, describe(c))})
+		}
+
+		modelDefault := contains(s.ColumnsWithDefault, name)
+		if modelDefault != c.HasDefault() {
+			diffs = append(diffs, Diff{Table: t.Name, Column: name, Field: "default", Model: defaultState(modelDefault), Database: fmt.Sprintf("%s (%s)", defaultState(c.HasDefault()), describe(c))})
+		}
+	}
+
+	for _, c := range t.Columns {
+		if !contains(s.Columns, c.Name) {
+			diffs = append(diffs, Diff{Table: t.Name, Column: c.Name, Database: describe(c)})
+		}
+	}
+
+	if strings.Join(s.PrimaryKey, ",") != strings.Join(t.PrimaryKey, ",") {
+		diffs = append(diffs, Diff{Table: t.Name, Field: "primary key", Model: keyList(s.PrimaryKey), Database: keyList(t.PrimaryKey)})
+	}
+
+	return diffs
+}
+
+// WriteReport は食い違いをテーブルごとにまとめて w に書き出す
+func WriteReport(w io.Writer, diffs []Diff) {
+	if len(diffs) == 0 {
+		fmt.Fprintln(w, "models are in sync with the database schema")
+		return
+	}
+
+	fmt.Fprintln(w, "--- models")
+	fmt.Fprintln(w, "+++ database")
+
+	var table string
+	for _, d := range diffs {
+		if d.Table != table {
+			table = d.Table
+			fmt.Fprintf(w, "@@ %s @@\n", table)
+		}
+		fmt.Fprintln(w, d)
+	}
+}
+
+func describe(c Column) string {
+	parts := []string{c.DataType}
+	if !c.Nullable {
+		parts = append(parts, "NOT NULL")
+	}
+	if c.Default.Valid {
+		parts = append(parts, "DEFAULT "+c.Default.String)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func defaultState(has bool) string {
+	if has {
+		return "has default"
+	}
+	return "no default"
+}
+
+func presence(ok bool) string {
+	if ok {
+		return "present"
+	}
+	return ""
+}
+
+func keyList(cols []string) string {
+	return "(" + strings.Join(strmangle.IdentQuoteSlice('"', '"', cols), ", ") + ")"
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
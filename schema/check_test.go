@@ -0,0 +1,102 @@
+package schema
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/null/v8"
+
+	"sqlboiler-project/models"
+)
+
+// liveBooks は現在のマイグレーションを流した books テーブルを表す
+func liveBooks() *Table {
+	return &Table{
+		Name: "books",
+		Columns: []Column{
+			{Name: "id", DataType: "integer", UDTName: "int4", Default: null.StringFrom("nextval('books_id_seq'::regclass)")},
+			{Name: "title", DataType: "character varying", UDTName: "varchar"},
+			{Name: "author", DataType: "character varying", UDTName: "varchar"},
+			{Name: "published_year", DataType: "integer", UDTName: "int4", Nullable: true},
+			{Name: "created_at", DataType: "timestamp without time zone", UDTName: "timestamp", Nullable: true, Default: null.StringFrom("CURRENT_TIMESTAMP")},
+			{Name: "tenant_id", DataType: "integer", UDTName: "int4", Default: null.StringFrom("0")},
+			{Name: "seed_key", DataType: "text", UDTName: "text", Nullable: true},
+			{Name: "author_id", DataType: "integer", UDTName: "int4", Nullable: true},
+		},
+		PrimaryKey: []string{"id"},
+	}
+}
+
+func booksSchema(t *testing.T) []models.TableSchema {
+	t.Helper()
+
+	for _, s := range models.TableSchemas {
+		if s.Name == models.TableNames.Books {
+			return []models.TableSchema{s}
+		}
+	}
+
+	t.Fatal("books is missing from models.TableSchemas")
+	return nil
+}
+
+func TestCompareInSync(t *testing.T) {
+	t.Parallel()
+
+	tables := map[string]*Table{"books": liveBooks(), "schema_migrations": {Name: "schema_migrations"}}
+	diffs := Compare([]string{"books"}, booksSchema(t), tables, []string{"schema_migrations"})
+	if len(diffs) != 0 {
+		t.Errorf("want no diffs, got %v", diffs)
+	}
+}
+
+func TestCompareDrift(t *testing.T) {
+	t.Parallel()
+
+	books := liveBooks()
+	books.Columns[3].Nullable = false                                                              // published_year NOT NULL
+	books.Columns = append(books.Columns[:2], books.Columns[3:]...)                                // author を削除
+	books.Columns = append(books.Columns, Column{Name: "isbn", DataType: "text", UDTName: "text"}) // 新しいカラム
+
+	tables := map[string]*Table{"books": books, "authors": {Name: "authors"}}
+	diffs := Compare([]string{"books", "movies"}, booksSchema(t), tables, nil)
+
+	want := []string{
+		"- table movies: no metadata in models.TableSchemas (missing in database)",
+		"- column author: string (missing in database)",
+		"~ column published_year type: models null.Int, database int (integer NOT NULL)",
+		"~ column published_year default: models has default, database no default (integer NOT NULL)",
+		"+ column isbn: text NOT NULL (missing in models)",
+		"+ table authors: present (missing in models)",
+	}
+
+	var got []string
+	for _, d := range diffs {
+		got = append(got, d.String())
+	}
+
+	for _, w := range want {
+		found := false
+		for _, g := range got {
+			if g == w {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("missing diff %q in:\n%s", w, strings.Join(got, "\n"))
+		}
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	WriteReport(buf, []Diff{{Table: "books", Column: "isbn", Database: "text"}})
+
+	want := "--- models\n+++ database\n@@ books @@\n+ column isbn: text (missing in models)\n"
+	if buf.String() != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
@@ -0,0 +1,109 @@
+// Package schema は稼働中のデータベースのスキーマと生成済みモデルのメタデータを突き合わせ、
+// モデルの再生成漏れ（スキーマドリフト）を検出する。
+package schema
+
+import (
+	"context"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// Table は information_schema から読み取ったテーブル定義
+type Table struct {
+	Name       string
+	Columns    []Column
+	PrimaryKey []string
+}
+
+// Column は information_schema から読み取ったカラム定義
+type Column struct {
+	Name     string      `boil:"column_name"`
+	DataType string      `boil:"data_type"`
+	UDTName  string      `boil:"udt_name"`
+	Nullable bool        `boil:"is_nullable"`
+	Default  null.String `boil:"column_default"`
+	Identity bool        `boil:"is_identity"`
+}
+
+// HasDefault は INSERT 時に値を省略できるか（sqlboiler の ColumnsWithDefault と同じ判定）を返す
+func (c Column) HasDefault() bool {
+	return c.Default.Valid || c.Nullable || c.Identity
+}
+
+// Column は name のカラムを返す
+func (t Table) Column(name string) (Column, bool) {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+
+	return Column{}, false
+}
+
+const columnsQuery = `
+SELECT
+    c.table_name,
+    c.column_name,
+    c.data_type,
+    c.udt_name,
+    c.is_nullable = 'YES' AS is_nullable,
+    c.column_default,
+    c.is_identity = 'YES' AS is_identity
+FROM information_schema.columns c
+JOIN information_schema.tables t
+    ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+WHERE c.table_schema = $1 AND t.table_type = 'BASE TABLE'
+ORDER BY c.table_name, c.ordinal_position`
+
+const primaryKeysQuery = `
+SELECT
+    tc.table_name,
+    kcu.column_name
+FROM information_schema.table_constraints tc
+JOIN information_schema.key_column_usage kcu
+    ON kcu.constraint_name = tc.constraint_name
+    AND kcu.table_schema = tc.table_schema
+    AND kcu.table_name = tc.table_name
+WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_schema = $1
+ORDER BY tc.table_name, kcu.ordinal_position`
+
+// Introspect は schemaName のテーブル定義をテーブル名をキーにして返す
+func Introspect(ctx context.Context, exec boil.ContextExecutor, schemaName string) (map[string]*Table, error) {
+	var columns []struct {
+		TableName string `boil:"table_name"`
+		Column    `boil:",bind"`
+	}
+	if err := queries.Raw(columnsQuery, schemaName).Bind(ctx, exec, &columns); err != nil {
+		return nil, errors.Wrap(err, "schema: unable to read columns")
+	}
+
+	tables := map[string]*Table{}
+	for _, c := range columns {
+		t, ok := tables[c.TableName]
+		if !ok {
+			t = &Table{Name: c.TableName}
+			tables[c.TableName] = t
+		}
+		t.Columns = append(t.Columns, c.Column)
+	}
+
+	var keys []struct {
+		TableName  string `boil:"table_name"`
+		ColumnName string `boil:"column_name"`
+	}
+	if err := queries.Raw(primaryKeysQuery, schemaName).Bind(ctx, exec, &keys); err != nil {
+		return nil, errors.Wrap(err, "schema: unable to read primary keys")
+	}
+
+	for _, k := range keys {
+		if t, ok := tables[k.TableName]; ok {
+			t.PrimaryKey = append(t.PrimaryKey, k.ColumnName)
+		}
+	}
+
+	return tables, nil
+}
@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeModel(t *testing.T, dir, name, header string) {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(header+"\n\npackage models\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGeneratorDiffs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeModel(t, dir, "books.go", "// Code generated by SQLBoiler 4.18.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.")
+	writeModel(t, dir, "books_search.go", "// 手で書いたファイル")
+
+	diffs, err := GeneratorDiffs(dir, "v4.18.0")
+	if err != nil || len(diffs) != 0 {
+		t.Errorf("same version: %v %v", diffs, err)
+	}
+
+	diffs, err = GeneratorDiffs(dir, "v4.19.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 1 || !strings.Contains(diffs[0].String(), "SQLBoiler 4.18.0 (1 files, e.g. books.go), go.mod requires "+sqlboilerModule+" v4.19.1") {
+		t.Errorf("want a mismatch naming books.go, got %v", diffs)
+	}
+
+	if _, err := GeneratorDiffs(t.TempDir(), "v4.18.0"); err == nil {
+		t.Error("want an error without generated models")
+	}
+}
+
+func TestLibraryVersion(t *testing.T) {
+	t.Parallel()
+
+	v, err := LibraryVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(v, "v4.") {
+		t.Errorf("unexpected version %q", v)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"sqlboiler-project/config"
+	"sqlboiler-project/schema"
+)
+
+// runSchema は schema サブコマンド。データベースのスキーマ・go.mod の SQLBoiler のバージョンと生成済みモデルを突き合わせる。
+// 食い違いがあれば diff を表示して終了コード 1 を返す。
+func runSchema(args []string) int {
+	if len(args) < 1 || args[0] != "check" {
+		fmt.Fprintln(os.Stderr, "usage: schema check [-config sqlboiler.toml] [-schema public] [-models models]")
+		return 2
+	}
+
+	fs := flag.NewFlagSet("schema check", flag.ContinueOnError)
+	flags := config.RegisterFlags(fs)
+	schemaName := fs.String("schema", "public", "Schema to compare with the models")
+	modelsDir := fs.String("models", "models", "Directory of the generated models")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	// モデル生成と同じ接続設定を使う
+	cfg, err := config.Load(flags)
+	if err != nil {
+		log.Printf("設定読み込みエラー: %v\n", err)
+		return 2
+	}
+
+	db, err := sql.Open("postgres", cfg.DSN())
+	if err != nil {
+		log.Printf("データベース接続エラー: %v\n", err)
+		return 2
+	}
+	defer db.Close()
+
+	diffs, err := schema.Check(context.Background(), db, *schemaName, cfg.PSQL.Blacklist)
+	if err != nil {
+		log.Printf("スキーマ取得エラー: %v\n", err)
+		return 2
+	}
+
+	// 生成に使った SQLBoiler と go.mod のライブラリのバージョンの食い違いも同じレポートに載せる
+	lib, err := schema.LibraryVersion()
+	if err != nil {
+		log.Println(err)
+		return 2
+	}
+	generator, err := schema.GeneratorDiffs(*modelsDir, lib)
+	if err != nil {
+		log.Println(err)
+		return 2
+	}
+	diffs = append(generator, diffs...)
+
+	schema.WriteReport(os.Stdout, diffs)
+	if len(diffs) != 0 {
+		return 1
+	}
+
+	return 0
+}
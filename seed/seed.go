@@ -0,0 +1,256 @@
+// Package seed は開発用のサンプルデータを投入する。
+// 同じ Options からは常に同じデータが作られ、自然キー（本はタイトルと著者、映画はタイトル、ユーザーはメールアドレス）で
+// Upsert するので何度流しても行は増えない。
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"sqlboiler-project/factories"
+	"sqlboiler-project/models"
+)
+
+// Options は投入するデータの量と乱数のシード
+type Options struct {
+	Seed             int64
+	Books            int
+	Users            int
+	Movies           int
+	FavoritesPerUser int
+	// Reset は投入前に既存のデータを削除する。削除と投入は同じトランザクションで行う。
+	Reset bool
+}
+
+// DefaultOptions は小さめのカタログ
+var DefaultOptions = Options{
+	Seed:             1,
+	Books:            50,
+	Users:            20,
+	Movies:           30,
+	FavoritesPerUser: 3,
+}
+
+// Upsert の衝突判定に使うカラム。
+// 本と映画のタイトルには一意制約が無いので、自然キーを seed_key に入れて使う（000007_add_seed_keys）。
+// ユーザーはメールアドレスがテナントごとに一意（000006_add_tenant_id_to_tables）。
+var (
+	bookConflictColumns  = []string{models.BookColumns.TenantID, models.BookColumns.SeedKey}
+	movieConflictColumns = []string{models.MovieColumns.TenantID, models.MovieColumns.SeedKey}
+	userConflictColumns  = []string{models.UserColumns.TenantID, models.UserColumns.Email}
+)
+
+// seedKey は自然キーのカラムの値をつないで seed_key の値にする。
+// 区切りはタイトルや名前に現れない制御文字（PostgreSQL の文字列に入れられない NUL は使えない）。
+func seedKey(parts ...string) null.String {
+	return null.StringFrom(strings.Join(parts, "\x1f"))
+}
+
+// Dataset は投入するデータ。お気に入りは Users と Movies の添字で表す。
+// Authors は Books の著者を重複なく並べたもので、本は AuthorName で著者に結ぶ。
+type Dataset struct {
+	Authors   models.AuthorSlice
+	Books     models.BookSlice
+	Users     models.UserSlice
+	Movies    models.MovieSlice
+	Favorites [][2]int
+}
+
+// Result は投入結果の件数
+type Result struct {
+	Deleted   int64
+	Authors   int
+	Books     int
+	Users     int
+	Movies    int
+	Favorites int
+}
+
+// Validate は Options の値を検査する
+func (o Options) Validate() error {
+	switch {
+	case o.Books < 0 || o.Users < 0 || o.Movies < 0 || o.FavoritesPerUser < 0:
+		return errors.New("seed: sizes must not be negative")
+	case o.FavoritesPerUser > o.Movies:
+		return errors.Errorf("seed: favorites per user (%d) exceeds the number of movies (%d)", o.FavoritesPerUser, o.Movies)
+	}
+
+	return nil
+}
+
+// Generate は opts から決定的にデータを作る
+func Generate(opts Options) (*Dataset, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	f := factories.NewWithSeed(opts.Seed)
+	rnd := rand.New(rand.NewSource(opts.Seed))
+	ds := &Dataset{}
+
+	books := map[string]bool{}
+	authors := map[string]bool{}
+	for i := 0; i < opts.Books; i++ {
+		o := f.Book().Build()
+		o.Title = uniqueTitle(books, o.Title+"\x00"+o.AuthorName, o.Title)
+		o.SeedKey = seedKey(o.Title, o.AuthorName)
+		ds.Books = append(ds.Books, o)
+
+		if !authors[o.AuthorName] {
+			authors[o.AuthorName] = true
+			ds.Authors = append(ds.Authors, &models.Author{Name: o.AuthorName})
+		}
+	}
+
+	movies := map[string]bool{}
+	for i := 0; i < opts.Movies; i++ {
+		o := f.Movie().Build()
+		o.Title = uniqueTitle(movies, o.Title, o.Title)
+		o.SeedKey = seedKey(o.Title)
+		ds.Movies = append(ds.Movies, o)
+	}
+
+	for i := 0; i < opts.Users; i++ {
+		ds.Users = append(ds.Users, f.User().Build())
+
+		for _, m := range rnd.Perm(opts.Movies)[:opts.FavoritesPerUser] {
+			ds.Favorites = append(ds.Favorites, [2]int{i, m})
+		}
+	}
+
+	return ds, nil
+}
+
+// uniqueTitle は key が重複する場合に続編のような番号をタイトルに付ける
+func uniqueTitle(seen map[string]bool, key, title string) string {
+	if !seen[key] {
+		seen[key] = true
+		return title
+	}
+
+	for n := 2; ; n++ {
+		numbered := fmt.Sprintf("%s %d", title, n)
+		k := fmt.Sprintf("%s %d", key, n)
+		if !seen[k] {
+			seen[k] = true
+			return numbered
+		}
+	}
+}
+
+// Run は opts のデータを 1 つのトランザクションで投入する
+func Run(ctx context.Context, db *sql.DB, opts Options) (*Result, error) {
+	ds, err := Generate(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "seed: unable to begin transaction")
+	}
+	defer tx.Rollback()
+
+	res := &Result{}
+	if opts.Reset {
+		if res.Deleted, err = Wipe(ctx, tx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = ds.Upsert(ctx, tx, res); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "seed: unable to commit")
+	}
+
+	return res, nil
+}
+
+// Wipe はシード対象のテーブルを依存の逆順に空にする
+func Wipe(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var total int64
+
+	deletes := []func() (int64, error){
+		func() (int64, error) { return models.UserFavoriteMovies().DeleteAll(ctx, exec) },
+		func() (int64, error) { return models.Users().DeleteAll(ctx, exec) },
+		func() (int64, error) { return models.Movies().DeleteAll(ctx, exec) },
+		func() (int64, error) { return models.Books().DeleteAll(ctx, exec) },
+		func() (int64, error) { return models.Authors().DeleteAll(ctx, exec) },
+	}
+	for _, del := range deletes {
+		n, err := del()
+		if err != nil {
+			return 0, errors.Wrap(err, "seed: unable to wipe")
+		}
+		total += n
+	}
+
+	return total, nil
+}
+
+// Upsert は自然キー（本と映画は seed_key）で Upsert し、件数を res に足す。
+// 著者は FindOrCreateAuthor で探すか作って本の author_id に入れる。
+// 映画とユーザーを先に保存し、RETURNING で得た id でお気に入りを結ぶ。
+func (ds *Dataset) Upsert(ctx context.Context, exec boil.ContextExecutor, res *Result) error {
+	authorIDs := make(map[string]int, len(ds.Authors))
+	for _, o := range ds.Authors {
+		// 既存の著者の表記は o.Name と違うことがあるので、生成した名前で引けるようにする
+		a, err := models.FindOrCreateAuthor(ctx, exec, o.Name)
+		if err != nil {
+			return errors.Wrapf(err, "seed: author %q", o.Name)
+		}
+		authorIDs[o.Name] = a.ID
+		*o = *a
+		res.Authors++
+	}
+
+	for _, o := range ds.Books {
+		if id, ok := authorIDs[o.AuthorName]; ok {
+			o.AuthorID = null.IntFrom(id)
+		}
+		err := o.Upsert(ctx, exec, true, bookConflictColumns, boil.Whitelist(models.BookColumns.PublishedYear, models.BookColumns.AuthorID), boil.Infer())
+		if err != nil {
+			return errors.Wrapf(err, "seed: book %q", o.Title)
+		}
+		res.Books++
+	}
+
+	for _, o := range ds.Movies {
+		err := o.Upsert(ctx, exec, true, movieConflictColumns, boil.Whitelist(models.MovieColumns.ReleaseYear), boil.Infer())
+		if err != nil {
+			return errors.Wrapf(err, "seed: movie %q", o.Title)
+		}
+		res.Movies++
+	}
+
+	for _, o := range ds.Users {
+		err := o.Upsert(ctx, exec, true, userConflictColumns, boil.Whitelist(models.UserColumns.Name), boil.Infer())
+		if err != nil {
+			return errors.Wrapf(err, "seed: user %q", o.Email)
+		}
+		res.Users++
+	}
+
+	for _, fav := range ds.Favorites {
+		o := &models.UserFavoriteMovie{
+			UserID:  ds.Users[fav[0]].ID,
+			MovieID: ds.Movies[fav[1]].ID,
+		}
+		if err := o.Upsert(ctx, exec, false, nil, boil.None(), boil.Infer()); err != nil {
+			return errors.Wrapf(err, "seed: favorite %d/%d", o.UserID, o.MovieID)
+		}
+		res.Favorites++
+	}
+
+	return nil
+}
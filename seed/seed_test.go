@@ -0,0 +1,191 @@
+package seed
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"sqlboiler-project/internal/testdb"
+	"sqlboiler-project/models"
+)
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	a, err := Generate(DefaultOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Generate(DefaultOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		t.Error("same options produced different datasets")
+	}
+
+	opts := DefaultOptions
+	opts.Seed++
+	c, err := Generate(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(a.Users, c.Users) {
+		t.Error("different seeds produced the same users")
+	}
+}
+
+func TestGenerateSizesAndNaturalKeys(t *testing.T) {
+	t.Parallel()
+
+	opts := Options{Seed: 7, Books: 300, Users: 100, Movies: 250, FavoritesPerUser: 4}
+	ds, err := Generate(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ds.Books) != opts.Books || len(ds.Users) != opts.Users || len(ds.Movies) != opts.Movies {
+		t.Fatalf("unexpected sizes: %d books, %d users, %d movies", len(ds.Books), len(ds.Users), len(ds.Movies))
+	}
+	if len(ds.Favorites) != opts.Users*opts.FavoritesPerUser {
+		t.Errorf("expected %d favorites, got %d", opts.Users*opts.FavoritesPerUser, len(ds.Favorites))
+	}
+
+	books := map[[2]string]bool{}
+	seedKeys := map[string]bool{}
+	for _, b := range ds.Books {
+		key := [2]string{b.Title, b.AuthorName}
+		if books[key] {
+			t.Errorf("duplicate book natural key %v", key)
+		}
+		books[key] = true
+
+		if !b.SeedKey.Valid || seedKeys[b.SeedKey.String] {
+			t.Errorf("book %v has a missing or duplicate seed_key %q", key, b.SeedKey.String)
+		}
+		seedKeys[b.SeedKey.String] = true
+	}
+
+	movies := map[string]bool{}
+	seedKeys = map[string]bool{}
+	for _, m := range ds.Movies {
+		if movies[m.Title] {
+			t.Errorf("duplicate movie title %q", m.Title)
+		}
+		movies[m.Title] = true
+
+		if !m.SeedKey.Valid || seedKeys[m.SeedKey.String] {
+			t.Errorf("movie %q has a missing or duplicate seed_key %q", m.Title, m.SeedKey.String)
+		}
+		seedKeys[m.SeedKey.String] = true
+	}
+
+	emails := map[string]bool{}
+	for _, u := range ds.Users {
+		if emails[u.Email] {
+			t.Errorf("duplicate email %q", u.Email)
+		}
+		emails[u.Email] = true
+	}
+
+	authors := map[string]bool{}
+	for _, a := range ds.Authors {
+		if authors[a.Name] {
+			t.Errorf("duplicate author %q", a.Name)
+		}
+		authors[a.Name] = true
+	}
+	for _, b := range ds.Books {
+		if !authors[b.AuthorName] {
+			t.Errorf("book %q has no author %q in the dataset", b.Title, b.AuthorName)
+		}
+	}
+
+	favorites := map[[2]int]bool{}
+	for _, f := range ds.Favorites {
+		if favorites[f] {
+			t.Errorf("duplicate favorite %v", f)
+		}
+		favorites[f] = true
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	if err := DefaultOptions.Validate(); err != nil {
+		t.Error(err)
+	}
+	if err := (Options{Books: -1}).Validate(); err == nil {
+		t.Error("expected an error for negative sizes")
+	}
+	if err := (Options{Movies: 2, FavoritesPerUser: 3}).Validate(); err == nil {
+		t.Error("expected an error when favorites exceed movies")
+	}
+}
+
+// 同じ Options で 2 回流しても行は増えず、本は全て著者に結ばれる
+func TestRunTwice(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.Open(t)
+	ctx := context.Background()
+	opts := Options{Seed: 3, Books: 20, Users: 5, Movies: 8, FavoritesPerUser: 2}
+
+	counts := func() [5]int64 {
+		t.Helper()
+
+		var ret [5]int64
+		var err error
+		for i, count := range []func() (int64, error){
+			func() (int64, error) { return models.Authors().Count(ctx, db) },
+			func() (int64, error) { return models.Books().Count(ctx, db) },
+			func() (int64, error) { return models.Users().Count(ctx, db) },
+			func() (int64, error) { return models.Movies().Count(ctx, db) },
+			func() (int64, error) { return models.UserFavoriteMovies().Count(ctx, db) },
+		} {
+			if ret[i], err = count(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return ret
+	}
+
+	first, err := Run(ctx, db, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := counts()
+	if after[0] == 0 || after[0] != int64(first.Authors) || after[1] != int64(opts.Books) || after[4] != int64(first.Favorites) {
+		t.Fatalf("unexpected rows after the first run: %v (%+v)", after, first)
+	}
+
+	if _, err = Run(ctx, db, opts); err != nil {
+		t.Fatal(err)
+	}
+	if again := counts(); again != after {
+		t.Errorf("second run changed row counts: %v -> %v", after, again)
+	}
+
+	unlinked, err := models.Books(models.BookWhere.AuthorID.IsNull()).Count(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unlinked != 0 {
+		t.Errorf("%d books have no author_id", unlinked)
+	}
+
+	// Reset は著者も消してから入れ直す
+	opts.Reset = true
+	res, err := Run(ctx, db, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Deleted != after[0]+after[1]+after[2]+after[3]+after[4] {
+		t.Errorf("deleted %d rows, want %d", res.Deleted, after[0]+after[1]+after[2]+after[3]+after[4])
+	}
+	if again := counts(); again != after {
+		t.Errorf("reset changed row counts: %v -> %v", after, again)
+	}
+}
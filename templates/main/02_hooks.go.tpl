@@ -0,0 +1,293 @@
+{{- if not .NoHooks -}}
+{{- $alias := .Aliases.Table .Table.Name}}
+{{- /*
+  sqlboiler 4.18.0 の main/02_hooks.go.tpl に、boil_hooks.go のフックの登録先（hookRegistry）を足したもの。
+  doXHooks は AddXHook で足したフックの後に、RegisterXHook で登録したフックと WithHooks で context に載せたフックを実行する。
+  一括操作のフック（XBulkOp・RegisterXBulkHook など）もここで生成し、16_update.go.tpl と 18_delete.go.tpl の UpdateAll / DeleteAll から実行する。
+  context を渡すコードの生成（no-context = false）を前提にしている。
+*/}}
+
+var {{$alias.DownSingular}}AfterSelectMu sync.Mutex
+var {{$alias.DownSingular}}AfterSelectHooks []{{$alias.UpSingular}}Hook
+
+{{if or (not .Table.IsView) (.Table.ViewCapabilities.CanInsert) -}}
+var {{$alias.DownSingular}}BeforeInsertMu sync.Mutex
+var {{$alias.DownSingular}}BeforeInsertHooks []{{$alias.UpSingular}}Hook
+var {{$alias.DownSingular}}AfterInsertMu sync.Mutex
+var {{$alias.DownSingular}}AfterInsertHooks []{{$alias.UpSingular}}Hook
+{{- end}}
+
+{{if not .Table.IsView -}}
+var {{$alias.DownSingular}}BeforeUpdateMu sync.Mutex
+var {{$alias.DownSingular}}BeforeUpdateHooks []{{$alias.UpSingular}}Hook
+var {{$alias.DownSingular}}AfterUpdateMu sync.Mutex
+var {{$alias.DownSingular}}AfterUpdateHooks []{{$alias.UpSingular}}Hook
+
+var {{$alias.DownSingular}}BeforeDeleteMu sync.Mutex
+var {{$alias.DownSingular}}BeforeDeleteHooks []{{$alias.UpSingular}}Hook
+var {{$alias.DownSingular}}AfterDeleteMu sync.Mutex
+var {{$alias.DownSingular}}AfterDeleteHooks []{{$alias.UpSingular}}Hook
+{{- end}}
+
+{{if or (not .Table.IsView) (.Table.ViewCapabilities.CanUpsert) -}}
+var {{$alias.DownSingular}}BeforeUpsertMu sync.Mutex
+var {{$alias.DownSingular}}BeforeUpsertHooks []{{$alias.UpSingular}}Hook
+var {{$alias.DownSingular}}AfterUpsertMu sync.Mutex
+var {{$alias.DownSingular}}AfterUpsertHooks []{{$alias.UpSingular}}Hook
+{{- end}}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *{{$alias.UpSingular}}) doAfterSelectHooks({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (err error) {
+	{{if not .NoContext -}}
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	{{end -}}
+	for _, hook := range {{$alias.DownSingular}}AfterSelectHooks {
+		if err := hook({{if not .NoContext}}ctx, {{end -}} exec, o); err != nil {
+			return err
+		}
+	}
+
+	return {{$alias.DownSingular}}Hooks.run(ctx, exec, boil.AfterSelectHook, o)
+}
+
+{{if or (not .Table.IsView) (.Table.ViewCapabilities.CanInsert) -}}
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *{{$alias.UpSingular}}) doBeforeInsertHooks({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (err error) {
+	{{if not .NoContext -}}
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	{{end -}}
+	for _, hook := range {{$alias.DownSingular}}BeforeInsertHooks {
+		if err := hook({{if not .NoContext}}ctx, {{end -}} exec, o); err != nil {
+			return err
+		}
+	}
+
+	return {{$alias.DownSingular}}Hooks.run(ctx, exec, boil.BeforeInsertHook, o)
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *{{$alias.UpSingular}}) doAfterInsertHooks({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (err error) {
+	{{if not .NoContext -}}
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	{{end -}}
+	for _, hook := range {{$alias.DownSingular}}AfterInsertHooks {
+		if err := hook({{if not .NoContext}}ctx, {{end -}} exec, o); err != nil {
+			return err
+		}
+	}
+
+	return {{$alias.DownSingular}}Hooks.run(ctx, exec, boil.AfterInsertHook, o)
+}
+{{- end}}
+
+{{if not .Table.IsView -}}
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *{{$alias.UpSingular}}) doBeforeUpdateHooks({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (err error) {
+	{{if not .NoContext -}}
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	{{end -}}
+	for _, hook := range {{$alias.DownSingular}}BeforeUpdateHooks {
+		if err := hook({{if not .NoContext}}ctx, {{end -}} exec, o); err != nil {
+			return err
+		}
+	}
+
+	return {{$alias.DownSingular}}Hooks.run(ctx, exec, boil.BeforeUpdateHook, o)
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *{{$alias.UpSingular}}) doAfterUpdateHooks({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (err error) {
+	{{if not .NoContext -}}
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	{{end -}}
+	for _, hook := range {{$alias.DownSingular}}AfterUpdateHooks {
+		if err := hook({{if not .NoContext}}ctx, {{end -}} exec, o); err != nil {
+			return err
+		}
+	}
+
+	return {{$alias.DownSingular}}Hooks.run(ctx, exec, boil.AfterUpdateHook, o)
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *{{$alias.UpSingular}}) doBeforeDeleteHooks({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (err error) {
+	{{if not .NoContext -}}
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	{{end -}}
+	for _, hook := range {{$alias.DownSingular}}BeforeDeleteHooks {
+		if err := hook({{if not .NoContext}}ctx, {{end -}} exec, o); err != nil {
+			return err
+		}
+	}
+
+	return {{$alias.DownSingular}}Hooks.run(ctx, exec, boil.BeforeDeleteHook, o)
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *{{$alias.UpSingular}}) doAfterDeleteHooks({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (err error) {
+	{{if not .NoContext -}}
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	{{end -}}
+	for _, hook := range {{$alias.DownSingular}}AfterDeleteHooks {
+		if err := hook({{if not .NoContext}}ctx, {{end -}} exec, o); err != nil {
+			return err
+		}
+	}
+
+	return {{$alias.DownSingular}}Hooks.run(ctx, exec, boil.AfterDeleteHook, o)
+}
+{{- end}}
+
+{{if or (not .Table.IsView) (.Table.ViewCapabilities.CanUpsert) -}}
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *{{$alias.UpSingular}}) doBeforeUpsertHooks({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (err error) {
+	{{if not .NoContext -}}
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	{{end -}}
+	for _, hook := range {{$alias.DownSingular}}BeforeUpsertHooks {
+		if err := hook({{if not .NoContext}}ctx, {{end -}} exec, o); err != nil {
+			return err
+		}
+	}
+
+	return {{$alias.DownSingular}}Hooks.run(ctx, exec, boil.BeforeUpsertHook, o)
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *{{$alias.UpSingular}}) doAfterUpsertHooks({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (err error) {
+	{{if not .NoContext -}}
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	{{end -}}
+	for _, hook := range {{$alias.DownSingular}}AfterUpsertHooks {
+		if err := hook({{if not .NoContext}}ctx, {{end -}} exec, o); err != nil {
+			return err
+		}
+	}
+
+	return {{$alias.DownSingular}}Hooks.run(ctx, exec, boil.AfterUpsertHook, o)
+}
+{{- end}}
+
+// Add{{$alias.UpSingular}}Hook registers your hook function for all future operations.
+func Add{{$alias.UpSingular}}Hook(hookPoint boil.HookPoint, {{$alias.DownSingular}}Hook {{$alias.UpSingular}}Hook) {
+	switch hookPoint {
+		case boil.AfterSelectHook:
+			{{$alias.DownSingular}}AfterSelectMu.Lock()
+			{{$alias.DownSingular}}AfterSelectHooks = append({{$alias.DownSingular}}AfterSelectHooks, {{$alias.DownSingular}}Hook)
+			{{$alias.DownSingular}}AfterSelectMu.Unlock()
+		{{- if or (not .Table.IsView) (.Table.ViewCapabilities.CanInsert)}}
+		case boil.BeforeInsertHook:
+			{{$alias.DownSingular}}BeforeInsertMu.Lock()
+			{{$alias.DownSingular}}BeforeInsertHooks = append({{$alias.DownSingular}}BeforeInsertHooks, {{$alias.DownSingular}}Hook)
+			{{$alias.DownSingular}}BeforeInsertMu.Unlock()
+		case boil.AfterInsertHook:
+			{{$alias.DownSingular}}AfterInsertMu.Lock()
+			{{$alias.DownSingular}}AfterInsertHooks = append({{$alias.DownSingular}}AfterInsertHooks, {{$alias.DownSingular}}Hook)
+			{{$alias.DownSingular}}AfterInsertMu.Unlock()
+		{{- end}}
+		{{- if not .Table.IsView}}
+		case boil.BeforeUpdateHook:
+			{{$alias.DownSingular}}BeforeUpdateMu.Lock()
+			{{$alias.DownSingular}}BeforeUpdateHooks = append({{$alias.DownSingular}}BeforeUpdateHooks, {{$alias.DownSingular}}Hook)
+			{{$alias.DownSingular}}BeforeUpdateMu.Unlock()
+		case boil.AfterUpdateHook:
+			{{$alias.DownSingular}}AfterUpdateMu.Lock()
+			{{$alias.DownSingular}}AfterUpdateHooks = append({{$alias.DownSingular}}AfterUpdateHooks, {{$alias.DownSingular}}Hook)
+			{{$alias.DownSingular}}AfterUpdateMu.Unlock()
+		case boil.BeforeDeleteHook:
+			{{$alias.DownSingular}}BeforeDeleteMu.Lock()
+			{{$alias.DownSingular}}BeforeDeleteHooks = append({{$alias.DownSingular}}BeforeDeleteHooks, {{$alias.DownSingular}}Hook)
+			{{$alias.DownSingular}}BeforeDeleteMu.Unlock()
+		case boil.AfterDeleteHook:
+			{{$alias.DownSingular}}AfterDeleteMu.Lock()
+			{{$alias.DownSingular}}AfterDeleteHooks = append({{$alias.DownSingular}}AfterDeleteHooks, {{$alias.DownSingular}}Hook)
+			{{$alias.DownSingular}}AfterDeleteMu.Unlock()
+		{{- end}}
+		{{- if or (not .Table.IsView) (.Table.ViewCapabilities.CanInsert)}}
+		case boil.BeforeUpsertHook:
+			{{$alias.DownSingular}}BeforeUpsertMu.Lock()
+			{{$alias.DownSingular}}BeforeUpsertHooks = append({{$alias.DownSingular}}BeforeUpsertHooks, {{$alias.DownSingular}}Hook)
+			{{$alias.DownSingular}}BeforeUpsertMu.Unlock()
+		case boil.AfterUpsertHook:
+			{{$alias.DownSingular}}AfterUpsertMu.Lock()
+			{{$alias.DownSingular}}AfterUpsertHooks = append({{$alias.DownSingular}}AfterUpsertHooks, {{$alias.DownSingular}}Hook)
+			{{$alias.DownSingular}}AfterUpsertMu.Unlock()
+		{{- end}}
+	}
+}
+
+var {{$alias.DownSingular}}Hooks = &hookRegistry[boil.HookPoint, *{{$alias.UpSingular}}]{table: "{{.Table.Name}}"}
+
+// Register{{$alias.UpSingular}}Hook は {{$alias.UpSingular}} のフックを登録し、登録を外す関数を返す
+func Register{{$alias.UpSingular}}Hook(hookPoint boil.HookPoint, hook {{$alias.UpSingular}}Hook, opts ...HookOption) (unregister func()) {
+	return {{$alias.DownSingular}}Hooks.register(hookPoint, hook, opts)
+}
+
+// {{$alias.UpSingular}}ContextHook は WithHooks で context に載せる {{$alias.UpSingular}} のフックを作る
+func {{$alias.UpSingular}}ContextHook(hookPoint boil.HookPoint, hook {{$alias.UpSingular}}Hook, opts ...HookOption) ContextHook {
+	return {{$alias.DownSingular}}Hooks.contextHook(hookPoint, hook, opts)
+}
+{{- if not .Table.IsView}}
+
+// {{$alias.UpSingular}}BulkOp は一括操作（UpdateAll / DeleteAll）の内容。クエリに対する操作なら Query、スライスに対する操作なら Slice が入る。
+type {{$alias.UpSingular}}BulkOp struct {
+	Query *queries.Query
+	Slice {{$alias.UpSingular}}Slice
+	// Cols は UpdateAll に渡された値。Before フックで書き換えると実行する UPDATE に反映される。DeleteAll では nil。
+	Cols M
+	// RowsAffected は実際に変わった行数。After フックでだけ入る。
+	RowsAffected int64
+}
+
+// {{$alias.UpSingular}}BulkHook は一括操作のフック。Before フックがエラーを返すと操作を取りやめ、そのエラーを返す。
+type {{$alias.UpSingular}}BulkHook func(context.Context, boil.ContextExecutor, *{{$alias.UpSingular}}BulkOp) error
+
+var {{$alias.DownSingular}}BulkHooks = &hookRegistry[BulkHookPoint, *{{$alias.UpSingular}}BulkOp]{table: "{{.Table.Name}}"}
+
+// Register{{$alias.UpSingular}}BulkHook は {{$alias.UpSingular}} の一括操作のフックを登録し、登録を外す関数を返す
+func Register{{$alias.UpSingular}}BulkHook(hookPoint BulkHookPoint, hook {{$alias.UpSingular}}BulkHook, opts ...HookOption) (unregister func()) {
+	return {{$alias.DownSingular}}BulkHooks.register(hookPoint, hook, opts)
+}
+
+// {{$alias.UpSingular}}BulkContextHook は WithHooks で context に載せる {{$alias.UpSingular}} の一括操作のフックを作る
+func {{$alias.UpSingular}}BulkContextHook(hookPoint BulkHookPoint, hook {{$alias.UpSingular}}BulkHook, opts ...HookOption) ContextHook {
+	return {{$alias.DownSingular}}BulkHooks.contextHook(hookPoint, hook, opts)
+}
+
+// do{{$alias.UpSingular}}BulkHooks は hookPoint の登録済みのフックと ctx のフックを実行する。boil.SkipHooks した context では何もしない。
+func do{{$alias.UpSingular}}BulkHooks(ctx context.Context, exec boil.ContextExecutor, hookPoint BulkHookPoint, op *{{$alias.UpSingular}}BulkOp) error {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	return {{$alias.DownSingular}}BulkHooks.run(ctx, exec, hookPoint, op)
+}
+{{- end}}
+{{- end}}
@@ -0,0 +1,420 @@
+{{- /*
+  sqlboiler 4.18.0 の main/16_update.go.tpl に、クエリとスライスの UpdateAll の前後で一括操作のフック（doXBulkHooks）を実行する処理を足したもの。
+  変更追跡（Track・Changed・UpdateChanged など）のメソッドもここで生成する。スナップショットの欄は 00_struct.go.tpl で足し、
+  FindX・One・All・ReloadAll と eager load で読み込んだ行のスナップショットを取る。
+  context と RowsAffected を使うコードの生成（no-context = false、no-rows-affected = false）を前提にしている。
+*/ -}}
+{{- if .Table.IsView -}}
+{{- else -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $schemaTable := .Table.Name | .SchemaTable}}
+{{if .AddGlobal -}}
+// UpdateG a single {{$alias.UpSingular}} record using the global executor.
+// See Update for more documentation.
+func (o *{{$alias.UpSingular}}) UpdateG({{if not .NoContext}}ctx context.Context, {{end -}} columns boil.Columns) {{if .NoRowsAffected}}error{{else}}(int64, error){{end -}} {
+	return o.Update({{if .NoContext}}boil.GetDB(){{else}}ctx, boil.GetContextDB(){{end}}, columns)
+}
+
+{{end -}}
+
+{{if .AddPanic -}}
+// UpdateP uses an executor to update the {{$alias.UpSingular}}, and panics on error.
+// See Update for more documentation.
+func (o *{{$alias.UpSingular}}) UpdateP({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, columns boil.Columns) {{if not .NoRowsAffected}}int64{{end -}} {
+	{{if not .NoRowsAffected}}rowsAff, {{end}}err := o.Update({{if not .NoContext}}ctx, {{end -}} exec, columns)
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+	{{- if not .NoRowsAffected}}
+
+	return rowsAff
+	{{end -}}
+}
+
+{{end -}}
+
+{{if and .AddGlobal .AddPanic -}}
+// UpdateGP a single {{$alias.UpSingular}} record using the global executor. Panics on error.
+// See Update for more documentation.
+func (o *{{$alias.UpSingular}}) UpdateGP({{if not .NoContext}}ctx context.Context, {{end -}} columns boil.Columns) {{if not .NoRowsAffected}}int64{{end -}} {
+	{{if not .NoRowsAffected}}rowsAff, {{end}}err := o.Update({{if .NoContext}}boil.GetDB(){{else}}ctx, boil.GetContextDB(){{end}}, columns)
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+	{{- if not .NoRowsAffected}}
+
+	return rowsAff
+	{{end -}}
+}
+
+{{end -}}
+
+// Update uses an executor to update the {{$alias.UpSingular}}.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *{{$alias.UpSingular}}) Update({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, columns boil.Columns) {{if .NoRowsAffected}}error{{else}}(int64, error){{end -}} {
+	{{- template "timestamp_update_helper" . -}}
+
+	var err error
+	{{if not .NoHooks -}}
+	if err = o.doBeforeUpdateHooks({{if not .NoContext}}ctx, {{end -}} exec); err != nil {
+		return {{if not .NoRowsAffected}}0, {{end -}} err
+	}
+	{{end -}}
+
+	key := makeCacheKey(columns, nil)
+	{{$alias.DownSingular}}UpdateCacheMut.RLock()
+	cache, cached := {{$alias.DownSingular}}UpdateCache[key]
+	{{$alias.DownSingular}}UpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			{{$alias.DownSingular}}AllColumns,
+			{{$alias.DownSingular}}PrimaryKeyColumns,
+		)
+		{{- if filterColumnsByAuto true .Table.Columns }}
+		wl = strmangle.SetComplement(wl, {{$alias.DownSingular}}GeneratedColumns)
+		{{end}}
+		{{if not .NoAutoTimestamps}}
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"{{or $.AutoColumns.Created "created_at"}}"})
+		}
+		{{end -}}
+		if len(wl) == 0 {
+			return {{if not .NoRowsAffected}}0, {{end -}} errors.New("{{.PkgName}}: unable to update {{.Table.Name}}, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE {{$schemaTable}} SET %s WHERE %s",
+			strmangle.SetParamNames("{{.LQ}}", "{{.RQ}}", {{if .Dialect.UseIndexPlaceholders}}1{{else}}0{{end}}, wl),
+			strmangle.WhereClause("{{.LQ}}", "{{.RQ}}", {{if .Dialect.UseIndexPlaceholders}}len(wl)+1{{else}}0{{end}}, {{$alias.DownSingular}}PrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping({{$alias.DownSingular}}Type, {{$alias.DownSingular}}Mapping, append(wl, {{$alias.DownSingular}}PrimaryKeyColumns...))
+		if err != nil {
+			return {{if not .NoRowsAffected}}0, {{end -}} err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	{{if .NoContext -}}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	{{else -}}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	{{end -}}
+
+	{{if .NoRowsAffected -}}
+		{{if .NoContext -}}
+	_, err = exec.Exec(cache.query, values...)
+		{{else -}}
+	_, err = exec.ExecContext(ctx, cache.query, values...)
+		{{end -}}
+	{{else -}}
+	var result sql.Result
+		{{if .NoContext -}}
+	result, err = exec.Exec(cache.query, values...)
+		{{else -}}
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+		{{end -}}
+	{{end -}}
+	if err != nil {
+		return {{if not .NoRowsAffected}}0, {{end -}} errors.Wrap(err, "{{.PkgName}}: unable to update {{.Table.Name}} row")
+	}
+
+	{{if not .NoRowsAffected -}}
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "{{.PkgName}}: failed to get rows affected by update for {{.Table.Name}}")
+	}
+
+	{{end -}}
+
+	if !cached {
+		{{$alias.DownSingular}}UpdateCacheMut.Lock()
+		{{$alias.DownSingular}}UpdateCache[key] = cache
+		{{$alias.DownSingular}}UpdateCacheMut.Unlock()
+	}
+
+	{{if not .NoHooks -}}
+	return {{if not .NoRowsAffected}}rowsAff, {{end -}} o.doAfterUpdateHooks({{if not .NoContext}}ctx, {{end -}} exec)
+	{{- else -}}
+	return {{if not .NoRowsAffected}}rowsAff, {{end -}} nil
+	{{- end}}
+}
+
+{{if .AddPanic -}}
+// UpdateAllP updates all rows with matching column names, and panics on error.
+func (q {{$alias.DownSingular}}Query) UpdateAllP({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, cols M) {{if not .NoRowsAffected}}int64{{end -}} {
+	{{if not .NoRowsAffected}}rowsAff, {{end -}} err := q.UpdateAll({{if not .NoContext}}ctx, {{end -}} exec, cols)
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+	{{- if not .NoRowsAffected}}
+
+	return rowsAff
+	{{end -}}
+}
+
+{{end -}}
+
+
+{{if .AddGlobal -}}
+// UpdateAllG updates all rows with the specified column values.
+func (q {{$alias.DownSingular}}Query) UpdateAllG({{if not .NoContext}}ctx context.Context, {{end -}} cols M) {{if .NoRowsAffected}}error{{else}}(int64, error){{end -}} {
+	return q.UpdateAll({{if .NoContext}}boil.GetDB(){{else}}ctx, boil.GetContextDB(){{end}}, cols)
+}
+
+{{end -}}
+
+
+{{if and .AddGlobal .AddPanic -}}
+// UpdateAllGP updates all rows with the specified column values, and panics on error.
+func (q {{$alias.DownSingular}}Query) UpdateAllGP({{if not .NoContext}}ctx context.Context, {{end -}} cols M) {{if not .NoRowsAffected}}int64{{end -}} {
+	{{if not .NoRowsAffected}}rowsAff, {{end -}} err := q.UpdateAll({{if .NoContext}}boil.GetDB(){{else}}ctx, boil.GetContextDB(){{end}}, cols)
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+	{{- if not .NoRowsAffected}}
+
+	return rowsAff
+	{{end -}}
+}
+
+{{end -}}
+
+
+// UpdateAll updates all rows with the specified column values.
+func (q {{$alias.DownSingular}}Query) UpdateAll({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, cols M) {{if .NoRowsAffected}}error{{else}}(int64, error){{end -}} {
+	op := &{{$alias.UpSingular}}BulkOp{Query: q.Query, Cols: cols}
+	if err := do{{$alias.UpSingular}}BulkHooks(ctx, exec, BeforeUpdateAllHook, op); err != nil {
+		return 0, err
+	}
+
+	queries.SetUpdate(q.Query, op.Cols)
+
+	{{if .NoRowsAffected -}}
+		{{if .NoContext -}}
+	_, err := q.Query.Exec(exec)
+		{{else -}}
+	_, err := q.Query.ExecContext(ctx, exec)
+		{{end -}}
+	{{else -}}
+		{{if .NoContext -}}
+	result, err := q.Query.Exec(exec)
+		{{else -}}
+	result, err := q.Query.ExecContext(ctx, exec)
+		{{end -}}
+	{{end -}}
+	if err != nil {
+		return {{if not .NoRowsAffected}}0, {{end -}} errors.Wrap(err, "{{.PkgName}}: unable to update all for {{.Table.Name}}")
+	}
+
+	{{if not .NoRowsAffected -}}
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "{{.PkgName}}: unable to retrieve rows affected for {{.Table.Name}}")
+	}
+
+	{{end -}}
+
+	op.RowsAffected = rowsAff
+	if err := do{{$alias.UpSingular}}BulkHooks(ctx, exec, AfterUpdateAllHook, op); err != nil {
+		return 0, err
+	}
+
+	return {{if not .NoRowsAffected}}rowsAff, {{end -}} nil
+}
+
+{{if .AddGlobal -}}
+// UpdateAllG updates all rows with the specified column values.
+func (o {{$alias.UpSingular}}Slice) UpdateAllG({{if not .NoContext}}ctx context.Context, {{end -}} cols M) {{if .NoRowsAffected}}error{{else}}(int64, error){{end -}} {
+	return o.UpdateAll({{if .NoContext}}boil.GetDB(){{else}}ctx, boil.GetContextDB(){{end}}, cols)
+}
+
+{{end -}}
+
+{{if and .AddGlobal .AddPanic -}}
+// UpdateAllGP updates all rows with the specified column values, and panics on error.
+func (o {{$alias.UpSingular}}Slice) UpdateAllGP({{if not .NoContext}}ctx context.Context, {{end -}} cols M) {{if not .NoRowsAffected}}int64{{end -}} {
+	{{if not .NoRowsAffected}}rowsAff, {{end -}} err := o.UpdateAll({{if .NoContext}}boil.GetDB(){{else}}ctx, boil.GetContextDB(){{end}}, cols)
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+	{{- if not .NoRowsAffected}}
+
+	return rowsAff
+	{{end -}}
+}
+
+{{end -}}
+
+{{if .AddPanic -}}
+// UpdateAllP updates all rows with the specified column values, and panics on error.
+func (o {{$alias.UpSingular}}Slice) UpdateAllP({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, cols M) {{if not .NoRowsAffected}}int64{{end -}} {
+	{{if not .NoRowsAffected}}rowsAff, {{end -}} err := o.UpdateAll({{if not .NoContext}}ctx, {{end -}} exec, cols)
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+	{{- if not .NoRowsAffected}}
+
+	return rowsAff
+	{{end -}}
+}
+
+{{end -}}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o {{$alias.UpSingular}}Slice) UpdateAll({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, cols M) {{if .NoRowsAffected}}error{{else}}(int64, error){{end -}} {
+	ln := int64(len(o))
+	if ln == 0 {
+		return {{if not .NoRowsAffected}}0, {{end -}} nil
+	}
+
+	op := &{{$alias.UpSingular}}BulkOp{Slice: o, Cols: cols}
+	if err := do{{$alias.UpSingular}}BulkHooks(ctx, exec, BeforeUpdateAllHook, op); err != nil {
+		return 0, err
+	}
+	cols = op.Cols
+
+	if len(cols) == 0 {
+		return {{if not .NoRowsAffected}}0, {{end -}} errors.New("{{.PkgName}}: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), {{$alias.DownSingular}}PrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE {{$schemaTable}} SET %s WHERE %s",
+		strmangle.SetParamNames("{{.LQ}}", "{{.RQ}}", {{if .Dialect.UseIndexPlaceholders}}1{{else}}0{{end}}, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), {{if .Dialect.UseIndexPlaceholders}}len(colNames)+1{{else}}0{{end}}, {{$alias.DownSingular}}PrimaryKeyColumns, len(o)))
+
+	{{if .NoContext -}}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	{{else -}}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	{{end -}}
+
+	{{if .NoRowsAffected -}}
+		{{if .NoContext -}}
+	_, err := exec.Exec(sql, args...)
+		{{else -}}
+	_, err := exec.ExecContext(ctx, sql, args...)
+		{{end -}}
+	{{else -}}
+		{{if .NoContext -}}
+	result, err := exec.Exec(sql, args...)
+		{{else -}}
+	result, err := exec.ExecContext(ctx, sql, args...)
+		{{end -}}
+	{{end -}}
+	if err != nil {
+		return {{if not .NoRowsAffected}}0, {{end -}} errors.Wrap(err, "{{.PkgName}}: unable to update all in {{$alias.DownSingular}} slice")
+	}
+
+	{{if not .NoRowsAffected -}}
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "{{.PkgName}}: unable to retrieve rows affected all in update all {{$alias.DownSingular}}")
+	}
+	{{end -}}
+
+	op.RowsAffected = rowsAff
+	if err := do{{$alias.UpSingular}}BulkHooks(ctx, exec, AfterUpdateAllHook, op); err != nil {
+		return 0, err
+	}
+
+	return {{if not .NoRowsAffected}}rowsAff, {{end -}} nil
+}
+
+{{- if not .Table.IsJoinTable}}
+
+var {{$alias.DownSingular}}AllColumnsMapping, _ = queries.BindMapping({{$alias.DownSingular}}Type, {{$alias.DownSingular}}Mapping, {{$alias.DownSingular}}AllColumns)
+
+// Track は現在の値をスナップショットにする。読み込んだ {{$alias.UpSingular}} では自動で取るので、Insert の後などに追跡を始めるときに使う。
+func (o *{{$alias.UpSingular}}) Track() {
+	o.snapshot = o.Copy()
+}
+
+// Snapshot は読み込んだ時点の値を返す。追跡していなければ ok は false になる。
+func (o *{{$alias.UpSingular}}) Snapshot() (snapshot *{{$alias.UpSingular}}, ok bool) {
+	if o.snapshot == nil {
+		return nil, false
+	}
+
+	return o.snapshot.Copy(), true
+}
+
+// Copy はカラムの値を複製した {{$alias.UpSingular}} を返す。R（読み込んだリレーション）とスナップショットは複製しない。
+func (o *{{$alias.UpSingular}}) Copy() *{{$alias.UpSingular}} {
+	c := *o
+	c.R = nil
+	c.snapshot = nil
+
+	return &c
+}
+
+// Diff{{$alias.UpPlural}} は a から b への変更をカラム順に返す
+func Diff{{$alias.UpPlural}}(a, b *{{$alias.UpSingular}}) []ColumnChange {
+	return diffColumns({{$alias.DownSingular}}AllColumns,
+		queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(a)), {{$alias.DownSingular}}AllColumnsMapping),
+		queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(b)), {{$alias.DownSingular}}AllColumnsMapping))
+}
+
+// Changed は読み込んだ時点から変わったカラムを返す。追跡していなければ nil を返す。
+func (o *{{$alias.UpSingular}}) Changed() []ColumnChange {
+	if o.snapshot == nil {
+		return nil
+	}
+
+	return Diff{{$alias.UpPlural}}(o.snapshot, o)
+}
+
+// UpdateChanged は読み込んだ時点から変わったカラムだけを UPDATE する。
+// 変更が無ければ何もしない。追跡していなければ Update(boil.Infer()) と同じになる。
+// 列の組み合わせごとに Update と同じ {{$alias.DownSingular}}UpdateCache を使う。
+// BeforeUpdate フックが変えたカラムは書き込まれないので、そうしたカラムは Update で書く。
+func (o *{{$alias.UpSingular}}) UpdateChanged(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o.snapshot == nil {
+		return o.Update(ctx, exec, boil.Infer())
+	}
+
+	cols, err := changedColumns("{{.Table.Name}}", Diff{{$alias.UpPlural}}(o.snapshot, o), {{$alias.DownSingular}}PrimaryKeyColumns)
+	if err != nil || len(cols) == 0 {
+		return 0, err
+	}
+
+	rowsAff, err := o.Update(ctx, exec, boil.Whitelist(cols...))
+	if err != nil {
+		return rowsAff, err
+	}
+
+	o.Track()
+	return rowsAff, nil
+}
+{{- end}}
+{{- end -}}
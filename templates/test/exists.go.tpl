@@ -0,0 +1,40 @@
+{{- /*
+  sqlboiler 4.18.0 の test/exists.go.tpl で、外部キーのあるテーブルの行を書き込む前に
+  insertXParents（models/psql_fixtures_test.go）で参照先の行を入れて向け直すようにしたもの。
+  randomize.Struct が外部キーのカラムに乱数を入れるときだけ呼ぶ。
+*/ -}}
+{{- $parentsOnInsert := false -}}
+{{- range $.Table.FKeys -}}
+	{{- $col := $.Table.GetColumn .Column -}}
+	{{- if eq $col.Default ""}}{{$parentsOnInsert = true}}{{end -}}
+{{- end -}}
+{{- $alias := .Aliases.Table .Table.Name}}
+func test{{$alias.UpPlural}}Exists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &{{$alias.UpSingular}}{}
+	if err = randomize.Struct(seed, o, {{$alias.DownSingular}}DBTypes, true, {{$alias.DownSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$alias.UpSingular}} struct: %s", err)
+	}
+
+	{{if not .NoContext}}ctx := context.Background(){{end}}
+	tx := MustTx({{if .NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}})
+	defer func() { _ = tx.Rollback() }()
+	{{if $parentsOnInsert -}}
+	insert{{$alias.UpSingular}}Parents(t, ctx, tx, o)
+	{{end -}}
+	if err = o.Insert({{if not .NoContext}}ctx, {{end -}} tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	{{$pkeyArgs := .Table.PKey.Columns | stringMap (aliasCols $alias) | prefixStringSlice (printf "%s." "o") | join ", " -}}
+	e, err := {{$alias.UpSingular}}Exists({{if not .NoContext}}ctx, {{end -}} tx, {{$pkeyArgs}})
+	if err != nil {
+		t.Errorf("Unable to check if {{$alias.UpSingular}} exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected {{$alias.UpSingular}}Exists to return true, but got false.")
+	}
+}
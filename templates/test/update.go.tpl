@@ -0,0 +1,151 @@
+{{- /*
+  sqlboiler 4.18.0 の test/update.go.tpl で、外部キーのあるテーブルの行を書き込む前に
+  insertXParents（models/psql_fixtures_test.go）で参照先の行を入れて向け直すようにしたもの。
+  randomize.Struct が外部キーのカラムに乱数を入れるときだけ呼ぶ。
+*/ -}}
+{{- $parentsOnInsert := false -}}
+{{- $parentsOnUpdate := false -}}
+{{- $pkey := $.Table.PKey.Columns -}}
+{{- range $.Table.FKeys -}}
+	{{- $col := $.Table.GetColumn .Column -}}
+	{{- if eq $col.Default ""}}{{$parentsOnInsert = true}}{{end -}}
+	{{- if not (containsAny $pkey .Column)}}{{$parentsOnUpdate = true}}{{end -}}
+{{- end -}}
+{{- $alias := .Aliases.Table .Table.Name}}
+func test{{$alias.UpPlural}}Update(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len({{$alias.DownSingular}}PrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len({{$alias.DownSingular}}AllColumns) == len({{$alias.DownSingular}}PrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &{{$alias.UpSingular}}{}
+	if err = randomize.Struct(seed, o, {{$alias.DownSingular}}DBTypes, true, {{$alias.DownSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$alias.UpSingular}} struct: %s", err)
+	}
+
+	{{if not .NoContext}}ctx := context.Background(){{end}}
+	tx := MustTx({{if .NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}})
+	defer func() { _ = tx.Rollback() }()
+	{{if $parentsOnInsert -}}
+	insert{{$alias.UpSingular}}Parents(t, ctx, tx, o)
+	{{end -}}
+	if err = o.Insert({{if not .NoContext}}ctx, {{end -}} tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := {{$alias.UpPlural}}().Count({{if not .NoContext}}ctx, {{end -}} tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, {{$alias.DownSingular}}DBTypes, true, {{$alias.DownSingular}}PrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize {{$alias.UpSingular}} struct: %s", err)
+	}
+	{{- if $parentsOnUpdate}}
+	insert{{$alias.UpSingular}}Parents(t, ctx, tx, o)
+	{{- end}}
+
+	{{if .NoRowsAffected -}}
+	if err = o.Update({{if not .NoContext}}ctx, {{end -}} tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	{{else -}}
+	if rowsAff, err := o.Update({{if not .NoContext}}ctx, {{end -}} tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+	{{end -}}
+}
+
+func test{{$alias.UpPlural}}SliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len({{$alias.DownSingular}}AllColumns) == len({{$alias.DownSingular}}PrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &{{$alias.UpSingular}}{}
+	if err = randomize.Struct(seed, o, {{$alias.DownSingular}}DBTypes, true, {{$alias.DownSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$alias.UpSingular}} struct: %s", err)
+	}
+
+	{{if not .NoContext}}ctx := context.Background(){{end}}
+	tx := MustTx({{if .NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}})
+	defer func() { _ = tx.Rollback() }()
+	{{if $parentsOnInsert -}}
+	insert{{$alias.UpSingular}}Parents(t, ctx, tx, o)
+	{{end -}}
+	if err = o.Insert({{if not .NoContext}}ctx, {{end -}} tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := {{$alias.UpPlural}}().Count({{if not .NoContext}}ctx, {{end -}} tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, {{$alias.DownSingular}}DBTypes, true, {{$alias.DownSingular}}PrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize {{$alias.UpSingular}} struct: %s", err)
+	}
+	{{- if $parentsOnUpdate}}
+	insert{{$alias.UpSingular}}Parents(t, ctx, tx, o)
+	{{- end}}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch({{$alias.DownSingular}}AllColumns, {{$alias.DownSingular}}PrimaryKeyColumns) {
+		fields = {{$alias.DownSingular}}AllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			{{$alias.DownSingular}}AllColumns,
+			{{$alias.DownSingular}}PrimaryKeyColumns,
+		)
+		{{- if filterColumnsByAuto true .Table.Columns }}
+		fields = strmangle.SetComplement(fields, {{$alias.DownSingular}}GeneratedColumns)
+		{{- end}}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := {{$alias.UpSingular}}Slice{{"{"}}o{{"}"}}
+	{{if .NoRowsAffected -}}
+	if err = slice.UpdateAll({{if not .NoContext}}ctx, {{end -}} tx, updateMap); err != nil {
+		t.Error(err)
+	}
+	{{else -}}
+	if rowsAff, err := slice.UpdateAll({{if not .NoContext}}ctx, {{end -}} tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+	{{end -}}
+}
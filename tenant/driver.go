@@ -0,0 +1,197 @@
+package tenant
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/friendsofgo/errors"
+	"github.com/lib/pq"
+)
+
+// connector は lib/pq のコネクタを包み、接続ごとにテナントのスコープを適用する
+type connector struct {
+	base driver.Connector
+	role string
+}
+
+// NewConnector は Open が使うコネクタを返す。sql.OpenDB に渡して使う。
+func NewConnector(dsn, role string) (driver.Connector, error) {
+	base, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, errors.Wrap(err, "tenant: unable to create connector")
+	}
+
+	return &connector{base: base, role: role}, nil
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	dc, err := c.base.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cn := &conn{Conn: dc}
+	if len(c.role) != 0 {
+		if _, err := cn.exec(ctx, "SET ROLE "+pq.QuoteIdentifier(c.role)); err != nil {
+			_ = dc.Close()
+			return nil, errors.Wrapf(err, "tenant: unable to set role %s", c.role)
+		}
+	}
+
+	return cn, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.base.Driver()
+}
+
+// conn は 1 本の物理接続。セッションに設定済みのテナントを覚えておき、変わったときだけ設定し直す。
+type conn struct {
+	driver.Conn
+
+	inTx    bool
+	scoped  bool
+	current string
+}
+
+func (c *conn) exec(ctx context.Context, query string, args ...driver.NamedValue) (driver.Result, error) {
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+}
+
+// scope はトランザクション外の文を実行する前にセッションの app.tenant_id を合わせる
+func (c *conn) scope(ctx context.Context) error {
+	if c.inTx {
+		return nil
+	}
+
+	v := settingValue(ctx)
+	if c.scoped && c.current == v {
+		return nil
+	}
+
+	if _, err := c.exec(ctx, "SELECT set_config($1, $2, false)", namedValues(settingKey, v)...); err != nil {
+		return errors.Wrap(err, "tenant: unable to set session tenant")
+	}
+
+	c.scoped = true
+	c.current = v
+	return nil
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	tx, err := c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// SET LOCAL app.tenant_id と同じ。トランザクション終了時に元に戻る
+	if _, err := c.exec(ctx, "SELECT set_config($1, $2, true)", namedValues(settingKey, settingValue(ctx))...); err != nil {
+		_ = tx.Rollback()
+		return nil, errors.Wrap(err, "tenant: unable to set transaction tenant")
+	}
+
+	c.inTx = true
+	return &txn{Tx: tx, c: c}, nil
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.scope(ctx); err != nil {
+		return nil, err
+	}
+	return c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.scope(ctx); err != nil {
+		return nil, err
+	}
+	return c.exec(ctx, query, args...)
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	st, err := c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &stmt{Stmt: st, c: c}, nil
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	return c.Conn.(driver.SessionResetter).ResetSession(ctx)
+}
+
+func (c *conn) IsValid() bool {
+	return c.Conn.(driver.Validator).IsValid()
+}
+
+type txn struct {
+	driver.Tx
+	c *conn
+}
+
+func (t *txn) Commit() error {
+	t.c.inTx = false
+	return t.Tx.Commit()
+}
+
+func (t *txn) Rollback() error {
+	t.c.inTx = false
+	return t.Tx.Rollback()
+}
+
+// stmt はプリペアドステートメント経由の実行にもスコープを適用する
+type stmt struct {
+	driver.Stmt
+	c *conn
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if err := s.c.scope(ctx); err != nil {
+		return nil, err
+	}
+	if se, ok := s.Stmt.(driver.StmtExecContext); ok {
+		return se.ExecContext(ctx, args)
+	}
+	vals, err := values(args)
+	if err != nil {
+		return nil, err
+	}
+	return s.Stmt.Exec(vals)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if err := s.c.scope(ctx); err != nil {
+		return nil, err
+	}
+	if sq, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		return sq.QueryContext(ctx, args)
+	}
+	vals, err := values(args)
+	if err != nil {
+		return nil, err
+	}
+	return s.Stmt.Query(vals)
+}
+
+func namedValues(vals ...interface{}) []driver.NamedValue {
+	ret := make([]driver.NamedValue, len(vals))
+	for i, v := range vals {
+		ret[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return ret
+}
+
+func values(args []driver.NamedValue) ([]driver.Value, error) {
+	ret := make([]driver.Value, len(args))
+	for i, a := range args {
+		if len(a.Name) != 0 {
+			return nil, errors.Errorf("tenant: named parameter %s is not supported", a.Name)
+		}
+		ret[i] = a.Value
+	}
+	return ret, nil
+}
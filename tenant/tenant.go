@@ -0,0 +1,51 @@
+// Package tenant はコンテキストに載せたテナント ID を PostgreSQL のセッション変数 app.tenant_id に
+// 反映し、行レベルセキュリティ（RLS）で全てのクエリをテナント単位に絞り込むためのパッケージ。
+package tenant
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+)
+
+// DefaultRole はマイグレーションで作成される RLS 用のロール
+const DefaultRole = "app_tenant"
+
+// settingKey は RLS ポリシーが参照するセッション変数
+const settingKey = "app.tenant_id"
+
+type contextKey struct{}
+
+// WithTenant はテナント ID を載せたコンテキストを返す
+func WithTenant(ctx context.Context, tenantID int) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenantID)
+}
+
+// FromContext はコンテキストに載っているテナント ID を返す
+func FromContext(ctx context.Context) (int, bool) {
+	id, ok := ctx.Value(contextKey{}).(int)
+	return id, ok
+}
+
+// settingValue は app.tenant_id に設定する値を返す。テナントが無ければ空文字（どの行も見えない）
+func settingValue(ctx context.Context) string {
+	if id, ok := FromContext(ctx); ok {
+		return strconv.Itoa(id)
+	}
+	return ""
+}
+
+// Open はテナントのスコープを自動で適用する *sql.DB を返す。
+// role が空でなければ接続ごとに SET ROLE してから使う（スーパーユーザーは RLS を素通りするため）。
+//
+// トランザクションは開始直後に SET LOCAL app.tenant_id 相当（set_config(..., true)）を実行し、
+// トランザクション外の文は実行前にセッションの app.tenant_id をそのコンテキストのテナントに合わせる。
+// そのため生成されたモデルのメソッドに ctx を渡すだけでテナント単位に絞り込まれる。
+func Open(dsn, role string) (*sql.DB, error) {
+	c, err := NewConnector(dsn, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.OpenDB(c), nil
+}
@@ -0,0 +1,166 @@
+package tenant
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"sqlboiler-project/models"
+)
+
+// recorder は実行された SQL を記録するだけのドライバ
+type recorder struct {
+	mu    sync.Mutex
+	stmts []string
+}
+
+func (r *recorder) record(query string, args []driver.NamedValue) {
+	vals := make([]string, len(args))
+	for i, a := range args {
+		vals[i] = fmt.Sprint(a.Value)
+	}
+
+	r.mu.Lock()
+	r.stmts = append(r.stmts, strings.TrimSpace(query+" "+strings.Join(vals, " ")))
+	r.mu.Unlock()
+}
+
+func (r *recorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ret := r.stmts
+	r.stmts = nil
+	return ret
+}
+
+func (r *recorder) Connect(context.Context) (driver.Conn, error) { return &recorderConn{r: r}, nil }
+func (r *recorder) Driver() driver.Driver                        { return nil }
+
+type recorderConn struct{ r *recorder }
+
+func (c *recorderConn) Prepare(query string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *recorderConn) Close() error                              { return nil }
+func (c *recorderConn) Begin() (driver.Tx, error)                 { return c, nil }
+func (c *recorderConn) Commit() error                             { c.r.record("COMMIT", nil); return nil }
+func (c *recorderConn) Rollback() error                           { c.r.record("ROLLBACK", nil); return nil }
+func (c *recorderConn) Ping(context.Context) error                { return nil }
+func (c *recorderConn) ResetSession(context.Context) error        { return nil }
+func (c *recorderConn) IsValid() bool                             { return true }
+
+func (c *recorderConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.r.record("BEGIN", nil)
+	return c, nil
+}
+
+func (c *recorderConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *recorderConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.r.record(query, args)
+	return driver.RowsAffected(0), nil
+}
+
+func (c *recorderConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.r.record(query, args)
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string              { return nil }
+func (emptyRows) Close() error                   { return nil }
+func (emptyRows) Next(dest []driver.Value) error { return io.EOF }
+
+func openRecorder(t *testing.T, role string) (*sql.DB, *recorder) {
+	t.Helper()
+
+	r := &recorder{}
+	db := sql.OpenDB(&connector{base: r, role: role})
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db, r
+}
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	if _, ok := FromContext(context.Background()); ok {
+		t.Error("want no tenant on a bare context")
+	}
+
+	id, ok := FromContext(WithTenant(context.Background(), 42))
+	if !ok || id != 42 {
+		t.Errorf("want tenant 42, got %d (%t)", id, ok)
+	}
+}
+
+func TestSessionScope(t *testing.T) {
+	t.Parallel()
+
+	db, r := openRecorder(t, DefaultRole)
+	ctx := WithTenant(context.Background(), 1)
+
+	if _, err := models.Books().All(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := models.Books().All(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := models.Books().All(WithTenant(context.Background(), 2), db); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := models.Books().All(context.Background(), db); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`SET ROLE "app_tenant"`,
+		"SELECT set_config($1, $2, false) app.tenant_id 1",
+		`SELECT "books".* FROM "books";`,
+		`SELECT "books".* FROM "books";`,
+		"SELECT set_config($1, $2, false) app.tenant_id 2",
+		`SELECT "books".* FROM "books";`,
+		"SELECT set_config($1, $2, false) app.tenant_id",
+		`SELECT "books".* FROM "books";`,
+	}
+	if got := r.take(); !reflect.DeepEqual(got, want) {
+		t.Errorf("want:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestTransactionScope(t *testing.T) {
+	t.Parallel()
+
+	db, r := openRecorder(t, "")
+	ctx := WithTenant(context.Background(), 7)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := models.Books().All(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"BEGIN",
+		"SELECT set_config($1, $2, true) app.tenant_id 7",
+		`SELECT "books".* FROM "books";`,
+		"COMMIT",
+	}
+	if got := r.take(); !reflect.DeepEqual(got, want) {
+		t.Errorf("want:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}